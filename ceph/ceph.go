@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"maps"
 	"os/exec"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -14,15 +18,40 @@ import (
 )
 
 type Ceph interface {
-	ApplyCephConfigOption(ctx context.Context, section, key, value string) error
+	ApplyCephConfigOption(ctx context.Context, target, key, value string) error
 	ApplyCephOSDConfigOption(ctx context.Context, key, value string) error
+	BalancerStatus(ctx context.Context) (models.BalancerStatus, error)
 	ClusterReport(ctx context.Context) (models.ClusterReport, error)
 	ClusterStatus(ctx context.Context) (models.ClusterStatus, error)
+	DaemonVersions(ctx context.Context) (map[string]map[string]uint16, error)
+	DisableMgrModule(ctx context.Context, name string) error
 	DumpConfig(ctx context.Context) (models.CephConfig, error)
+	EnableMgrModule(ctx context.Context, name string) error
+	GetMaintenanceLease(ctx context.Context) (*models.MaintenanceLease, error)
+	ListAuthEntities(ctx context.Context) ([]models.AuthEntity, error)
 	ListDevices(ctx context.Context) ([]models.Device, error)
-	RemoveCephConfigOption(ctx context.Context, section, key string) error
+	ListFileSystems(ctx context.Context) ([]models.FileSystem, error)
+	ListMgrModules(ctx context.Context) (models.MgrModules, error)
+	ListOSDUsage(ctx context.Context) ([]models.OSDUsage, error)
+	MuteHealthCheck(ctx context.Context, code string, ttl time.Duration, sticky bool) error
+	OSDOkToStop(ctx context.Context, ids []uint16) (models.OSDOkToStop, error)
+	OSDSafeToDestroy(ctx context.Context, ids []uint16) (models.OSDSafeToDestroy, error)
+	RemoveCephConfigOption(ctx context.Context, target, key string) error
+	RemoveMaintenanceLease(ctx context.Context) error
+	SetAuthCaps(ctx context.Context, entity string, caps map[string]string) error
+	SetBalancerActive(ctx context.Context, active bool) error
+	SetBalancerMode(ctx context.Context, mode string) error
+	SetFileSystemOption(ctx context.Context, fs, key, value string) error
+	SetMaintenanceLease(ctx context.Context, lease models.MaintenanceLease) error
+	SetOSDFlag(ctx context.Context, flag, who string) error
+	UnmuteHealthCheck(ctx context.Context, code string) error
+	UnsetOSDFlag(ctx context.Context, flag, who string) error
 }
 
+// maintenanceLeaseKey is the config-key store key the maintenance lease
+// is persisted at
+const maintenanceLeaseKey = "cephctl/maintenance"
+
 type ceph struct {
 	binaryPath string
 }
@@ -33,8 +62,10 @@ func New(binaryPath string) Ceph {
 	}
 }
 
-func (c *ceph) ApplyCephConfigOption(ctx context.Context, section, key, value string) error {
-	bin, args := mkCommand(c.binaryPath, []string{"config", "set", section, key, value})
+// ApplyCephConfigOption sets the option for the target which is
+// section optionally followed by mask, i.e. `osd/class:ssd`
+func (c *ceph) ApplyCephConfigOption(ctx context.Context, target, key, value string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"config", "set", target, key, value})
 
 	cmd := exec.CommandContext(ctx, bin, args...)
 	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
@@ -72,6 +103,27 @@ func (c *ceph) ApplyCephOSDConfigOption(ctx context.Context, key, value string)
 	return nil
 }
 
+func (c *ceph) BalancerStatus(ctx context.Context) (models.BalancerStatus, error) {
+	st := cephModels.BalancerStatus{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"balancer", "status", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return models.BalancerStatus{}, errors.Wrap(err, "error retrieving balancer status")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &st); err != nil {
+		return models.BalancerStatus{}, errors.Wrap(err, "error decoding response")
+	}
+
+	return st.ToSvc(), nil
+}
+
 func (c *ceph) ClusterReport(ctx context.Context) (models.ClusterReport, error) {
 	buf := &bytes.Buffer{}
 	bin, args := mkCommand(c.binaryPath, []string{"report", "--format=json"})
@@ -114,6 +166,39 @@ func (c ceph) ClusterStatus(ctx context.Context) (models.ClusterStatus, error) {
 	return st.ToSvc()
 }
 
+// DaemonVersions returns amount of running daemons by version per daemon type
+func (c *ceph) DaemonVersions(ctx context.Context) (map[string]map[string]uint16, error) {
+	versions := cephModels.Versions{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"versions", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "error retrieving daemon versions")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &versions); err != nil {
+		return nil, errors.Wrap(err, "error decoding response")
+	}
+
+	return versions.ToSvc(), nil
+}
+
+func (c *ceph) DisableMgrModule(ctx context.Context, name string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"mgr", "module", "disable", name})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error disabling manager module")
+	}
+	return nil
+}
+
 func (c *ceph) DumpConfig(ctx context.Context) (models.CephConfig, error) {
 	cfg := []cephModels.ConfigOption{}
 	buf := &bytes.Buffer{}
@@ -133,16 +218,83 @@ func (c *ceph) DumpConfig(ctx context.Context) (models.CephConfig, error) {
 
 	out := make(models.CephConfig)
 	for _, v := range cfg {
-		if _, ok := out[v.Section]; !ok {
-			out[v.Section] = make(map[string]string)
+		target := models.ConfigTarget(v.Section, v.Mask)
+		if _, ok := out[target]; !ok {
+			out[target] = make(map[string]string)
 		}
 
-		out[v.Section][v.Name] = v.Value
+		out[target][v.Name] = v.Value
 	}
 
 	return out, nil
 }
 
+func (c *ceph) EnableMgrModule(ctx context.Context, name string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"mgr", "module", "enable", name})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error enabling manager module")
+	}
+	return nil
+}
+
+// GetMaintenanceLease returns maintenance lease stored in config-key store
+// or nil if there's no maintenance in progress
+func (c *ceph) GetMaintenanceLease(ctx context.Context) (*models.MaintenanceLease, error) {
+	keys := map[string]string{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"config-key", "dump", maintenanceLeaseKey})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "error retrieving maintenance lease")
+	}
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &keys); err != nil {
+		return nil, errors.Wrap(err, "error decoding response")
+	}
+
+	// config-key dump matches keys by prefix
+	v, ok := keys[maintenanceLeaseKey]
+	if !ok {
+		return nil, nil
+	}
+
+	lease := cephModels.MaintenanceLease{}
+	if err := json.Unmarshal([]byte(v), &lease); err != nil {
+		return nil, errors.Wrap(err, "error decoding maintenance lease")
+	}
+
+	out := lease.ToSvc()
+	return &out, nil
+}
+
+// ListAuthEntities returns CephX entities with their keys, the command
+// output is not traced to keep keys out of logs
+func (c *ceph) ListAuthEntities(ctx context.Context) ([]models.AuthEntity, error) {
+	entities := cephModels.AuthList{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"auth", "ls", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "error listing auth entities")
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &entities); err != nil {
+		return nil, errors.Wrap(err, "error decoding response")
+	}
+
+	return entities.ToSvc(), nil
+}
+
 func (c *ceph) ListDevices(ctx context.Context) ([]models.Device, error) {
 	devices := []cephModels.Device{}
 	buf := &bytes.Buffer{}
@@ -171,8 +323,144 @@ func (c *ceph) ListDevices(ctx context.Context) ([]models.Device, error) {
 	return out, nil
 }
 
-func (c *ceph) RemoveCephConfigOption(ctx context.Context, section, key string) error {
-	bin, args := mkCommand(c.binaryPath, []string{"config", "rm", section, key})
+// ListFileSystems returns filesystems settings from FSMap
+func (c *ceph) ListFileSystems(ctx context.Context) ([]models.FileSystem, error) {
+	fsmap := cephModels.ReportFSMap{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"fs", "dump", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "error retrieving FSMap")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &fsmap); err != nil {
+		return nil, errors.Wrap(err, "error decoding response")
+	}
+
+	return fsmap.ToSvc(), nil
+}
+
+func (c *ceph) ListMgrModules(ctx context.Context) (models.MgrModules, error) {
+	modules := cephModels.MgrModuleList{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"mgr", "module", "ls", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return models.MgrModules{}, errors.Wrap(err, "error listing manager modules")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &modules); err != nil {
+		return models.MgrModules{}, errors.Wrap(err, "error decoding response")
+	}
+
+	return modules.ToSvc(), nil
+}
+
+// ListOSDUsage returns space usage of each OSD
+func (c *ceph) ListOSDUsage(ctx context.Context) ([]models.OSDUsage, error) {
+	df := cephModels.OSDDf{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, []string{"osd", "df", "--format=json"})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "error retrieving OSD usage")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &df); err != nil {
+		return nil, errors.Wrap(err, "error decoding response")
+	}
+
+	return df.ToSvc(), nil
+}
+
+// MuteHealthCheck mutes the health check for ttl or permanently if ttl
+// is zero, sticky mute is kept even if the check is cleared
+func (c *ceph) MuteHealthCheck(ctx context.Context, code string, ttl time.Duration, sticky bool) error {
+	keyArgs := []string{"health", "mute", code}
+	if ttl > 0 {
+		keyArgs = append(keyArgs, strconv.FormatInt(int64(ttl.Seconds()), 10)+"s")
+	}
+	if sticky {
+		keyArgs = append(keyArgs, "--sticky")
+	}
+
+	bin, args := mkCommand(c.binaryPath, keyArgs)
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error muting health check")
+	}
+	return nil
+}
+
+// OSDOkToStop checks whether OSDs could be stopped without PGs becoming
+// inactive. Ceph exits with EBUSY when it's not ok to stop OSDs but still
+// prints the details so they're returned with no error.
+func (c *ceph) OSDOkToStop(ctx context.Context, ids []uint16) (models.OSDOkToStop, error) {
+	out := cephModels.OSDOkToStop{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, append(append([]string{"osd", "ok-to-stop"}, osdIDArgs(ids)...), "--format=json"))
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil && !hasOutput(err, buf) {
+		return models.OSDOkToStop{}, errors.Wrap(err, "error checking OSDs are ok to stop")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		return models.OSDOkToStop{}, errors.Wrap(err, "error decoding response")
+	}
+
+	return out.ToSvc(), nil
+}
+
+// OSDSafeToDestroy checks whether OSDs could be destroyed without reducing
+// data durability. Ceph exits with EBUSY when it's not safe to destroy OSDs
+// but still prints the details so they're returned with no error.
+func (c *ceph) OSDSafeToDestroy(ctx context.Context, ids []uint16) (models.OSDSafeToDestroy, error) {
+	out := cephModels.OSDSafeToDestroy{}
+	buf := &bytes.Buffer{}
+	bin, args := mkCommand(c.binaryPath, append(append([]string{"osd", "safe-to-destroy"}, osdIDArgs(ids)...), "--format=json"))
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = buf
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil && !hasOutput(err, buf) {
+		return models.OSDSafeToDestroy{}, errors.Wrap(err, "error checking OSDs are safe to destroy")
+	}
+
+	log.Tracef("command output: `%s`", buf.String())
+
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		return models.OSDSafeToDestroy{}, errors.Wrap(err, "error decoding response")
+	}
+
+	return out.ToSvc(), nil
+}
+
+// RemoveCephConfigOption removes the option for the target which is
+// section optionally followed by mask, i.e. `osd/class:ssd`
+func (c *ceph) RemoveCephConfigOption(ctx context.Context, target, key string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"config", "rm", target, key})
 
 	cmd := exec.CommandContext(ctx, bin, args...)
 	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
@@ -181,3 +469,149 @@ func (c *ceph) RemoveCephConfigOption(ctx context.Context, section, key string)
 	}
 	return nil
 }
+
+// RemoveMaintenanceLease removes maintenance lease from config-key store
+func (c *ceph) RemoveMaintenanceLease(ctx context.Context) error {
+	bin, args := mkCommand(c.binaryPath, []string{"config-key", "rm", maintenanceLeaseKey})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error removing maintenance lease")
+	}
+	return nil
+}
+
+// SetAuthCaps replaces all the capabilities of the entity with caps
+func (c *ceph) SetAuthCaps(ctx context.Context, entity string, caps map[string]string) error {
+	keyArgs := []string{"auth", "caps", entity}
+	for _, daemon := range slices.Sorted(maps.Keys(caps)) {
+		keyArgs = append(keyArgs, daemon, caps[daemon])
+	}
+
+	bin, args := mkCommand(c.binaryPath, keyArgs)
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error setting auth caps")
+	}
+	return nil
+}
+
+func (c *ceph) SetBalancerActive(ctx context.Context, active bool) error {
+	state := "off"
+	if active {
+		state = "on"
+	}
+
+	bin, args := mkCommand(c.binaryPath, []string{"balancer", state})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error switching balancer")
+	}
+	return nil
+}
+
+func (c *ceph) SetBalancerMode(ctx context.Context, mode string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"balancer", "mode", mode})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error setting balancer mode")
+	}
+	return nil
+}
+
+func (c *ceph) SetFileSystemOption(ctx context.Context, fs, key, value string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"fs", "set", fs, key, value})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error setting filesystem option")
+	}
+	return nil
+}
+
+// SetMaintenanceLease persists maintenance lease in config-key store
+func (c *ceph) SetMaintenanceLease(ctx context.Context, lease models.MaintenanceLease) error {
+	data, err := json.Marshal(cephModels.NewMaintenanceLease(lease))
+	if err != nil {
+		return errors.Wrap(err, "error encoding maintenance lease")
+	}
+
+	bin, args := mkCommand(c.binaryPath, []string{"config-key", "set", maintenanceLeaseKey, string(data)})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error storing maintenance lease")
+	}
+	return nil
+}
+
+// SetOSDFlag sets the flag cluster-wide if who is empty or for the
+// particular OSD, CRUSH node or device class otherwise
+func (c *ceph) SetOSDFlag(ctx context.Context, flag, who string) error {
+	keyArgs := []string{"osd", "set", flag}
+	if who != "" {
+		keyArgs = []string{"osd", "set-group", flag, who}
+	}
+
+	bin, args := mkCommand(c.binaryPath, keyArgs)
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error setting OSD flag")
+	}
+	return nil
+}
+
+func (c *ceph) UnmuteHealthCheck(ctx context.Context, code string) error {
+	bin, args := mkCommand(c.binaryPath, []string{"health", "unmute", code})
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error unmuting health check")
+	}
+	return nil
+}
+
+// UnsetOSDFlag unsets the flag cluster-wide if who is empty or for the
+// particular OSD, CRUSH node or device class otherwise
+func (c *ceph) UnsetOSDFlag(ctx context.Context, flag, who string) error {
+	keyArgs := []string{"osd", "unset", flag}
+	if who != "" {
+		keyArgs = []string{"osd", "unset-group", flag, who}
+	}
+
+	bin, args := mkCommand(c.binaryPath, keyArgs)
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = log.StandardLogger().WriterLevel(log.DebugLevel)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "error unsetting OSD flag")
+	}
+	return nil
+}
+
+func osdIDArgs(ids []uint16) []string {
+	out := []string{}
+	for _, id := range ids {
+		out = append(out, strconv.FormatUint(uint64(id), 10))
+	}
+	return out
+}
+
+// hasOutput reports whether the command exited with non-zero code but
+// printed some output anyway
+func hasOutput(err error, buf *bytes.Buffer) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && buf.Len() > 0
+}
@@ -3,6 +3,7 @@ package ceph
 import (
 	"context"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -39,9 +40,366 @@ func TestApplyCephOSDConfigOptionInvalidKey(t *testing.T) {
 	r.Equal("unexpected key: `key`", err.Error())
 }
 
+func TestBalancerStatus(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_BalancerStatus")
+	st, err := c.BalancerStatus(context.Background())
+	r.NoError(err)
+	r.Equal(models.BalancerStatus{
+		Mode:                 "upmap",
+		Active:               true,
+		NoOptimizationNeeded: true,
+		OptimizeResult:       "Unable to find further optimization, or pool(s) pg_num is decreasing, or distribution is already perfect",
+	}, st)
+}
+
 func TestClusterReport(t *testing.T) {
 	r := require.New(t)
 
+	crushMap := models.CRUSHMap{
+		Buckets: []models.CRUSHBucket{
+			{
+				ID:   -1,
+				Name: "default",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -7, Weight: 4.2319793701171875},
+					{ID: -10, Weight: 4.2319793701171875},
+					{ID: -3, Weight: 4.2319793701171875},
+					{ID: -13, Weight: 4.2319793701171875},
+					{ID: -16, Weight: 4.2319793701171875},
+				},
+			},
+			{
+				ID:   -2,
+				Name: "default~ssd",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -8, Weight: 0.8319854736328125},
+					{ID: -11, Weight: 0.8319854736328125},
+					{ID: -4, Weight: 0.8319854736328125},
+					{ID: -14, Weight: 0.8319854736328125},
+					{ID: -17, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -3,
+				Name: "nuc01",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+					{ID: 1, Weight: 1.6999969482421875},
+					{ID: 2, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -4,
+				Name: "nuc01~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -5,
+				Name: "nuc01~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 1, Weight: 1.6999969482421875},
+					{ID: 2, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -6,
+				Name: "default~nvme",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -9, Weight: 3.399993896484375},
+					{ID: -12, Weight: 3.399993896484375},
+					{ID: -5, Weight: 3.399993896484375},
+					{ID: -15, Weight: 3.399993896484375},
+					{ID: -18, Weight: 3.399993896484375},
+				},
+			},
+			{
+				ID:   -7,
+				Name: "nuc02",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 3, Weight: 0.8319854736328125},
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -8,
+				Name: "nuc02~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 3, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -9,
+				Name: "nuc02~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -10,
+				Name: "nuc03",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -11,
+				Name: "nuc03~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -12,
+				Name: "nuc03~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -13,
+				Name: "nuc04",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -14,
+				Name: "nuc04~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -15,
+				Name: "nuc04~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -16,
+				Name: "nuc05",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -17,
+				Name: "nuc05~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -18,
+				Name: "nuc05~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+				},
+			},
+		},
+		Rules: []models.CRUSHRule{
+			{ID: 0, Name: "replicated_rule", Root: -1, FailureDomain: "host"},
+			{ID: 1, Name: "replicated_osd_nvme", Root: -6, FailureDomain: "osd"},
+			{ID: 2, Name: "replicated_osd_ssd", Root: -2, FailureDomain: "osd"},
+			{ID: 3, Name: "replicated_host_nvme", Root: -6, FailureDomain: "host"},
+			{ID: 4, Name: "replicated_host_ssd", Root: -2, FailureDomain: "host"},
+			{ID: 5, Name: "ec-6-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 6, Name: "ec-11-4-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 7, Name: "ec-10-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 8, Name: "ec-4-1-host", Root: -1, FailureDomain: "host"},
+		},
+		Tunables: models.CRUSHTunables{
+			Profile:                "jewel",
+			OptimalTunables:        true,
+			MinimumRequiredVersion: "jewel",
+		},
+	}
+
+	pools := []models.Pool{
+		{
+			ID:             1,
+			Name:           ".mgr",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 110309376,
+		},
+		{
+			ID:             2,
+			Name:           "volumes",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 103135297536,
+		},
+		{
+			ID:             3,
+			Name:           "shared-data_data",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      0,
+			AllocatedBytes: 65968693248,
+		},
+		{
+			ID:             4,
+			Name:           "shared-data_metadata",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 40009728,
+		},
+		{
+			ID:             5,
+			Name:           ".rgw.root",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 49152,
+		},
+		{
+			ID:             6,
+			Name:           "default.rgw.log",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 1903521792,
+		},
+		{
+			ID:             7,
+			Name:           "default.rgw.control",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 0,
+		},
+		{
+			ID:             8,
+			Name:           "default.rgw.meta",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 909312,
+		},
+		{
+			ID:             9,
+			Name:           "default.rgw.buckets.index",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 0,
+		},
+		{
+			ID:             11,
+			Name:           "default.rgw.buckets.non-ec",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      0,
+			AllocatedBytes: 10149888,
+		},
+		{
+			ID:             24,
+			Name:           "k8s01-pv-replicated",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      3,
+			AllocatedBytes: 15527571456,
+		},
+		{
+			ID:             31,
+			Name:           "volumes-ec-data-4-1-host",
+			Type:           models.PoolTypeErasure,
+			Size:           5,
+			MinSize:        4,
+			CrushRule:      8,
+			ErasureCodeK:   4,
+			ErasureCodeM:   1,
+			AllocatedBytes: 7380515061760,
+		},
+		{
+			ID:             32,
+			Name:           "volumes-ec-meta-4-1-host",
+			Type:           models.PoolTypeReplicated,
+			Size:           3,
+			MinSize:        2,
+			CrushRule:      0,
+			AllocatedBytes: 1683456,
+		},
+		{
+			ID:             48,
+			Name:           "default.rgw.buckets.data",
+			Type:           models.PoolTypeErasure,
+			Size:           5,
+			MinSize:        4,
+			CrushRule:      8,
+			ErasureCodeK:   4,
+			ErasureCodeM:   1,
+			AllocatedBytes: 3582816440320,
+		},
+	}
+
+	osdStates := []models.OSDState{
+		{ID: 0, Up: true, In: true, UpFrom: 24372, DownAt: 24369, DownStamp: time.Date(2024, 4, 22, 21, 33, 9, 658113000, time.UTC)},
+		{ID: 1, Up: true, In: true, UpFrom: 24926, DownAt: 24925, DownStamp: time.Date(2024, 4, 27, 6, 2, 21, 224403000, time.UTC), LaggyProbability: 0.30000001192092896},
+		{ID: 2, Up: true, In: true, UpFrom: 24462, DownAt: 24369, DownStamp: time.Date(2024, 4, 22, 21, 33, 41, 958226000, time.UTC)},
+		{ID: 3, Up: true, In: true, UpFrom: 24642, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 33, 5, 69120000, time.UTC)},
+		{ID: 4, Up: true, In: true, UpFrom: 24652, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 33, 32, 814547000, time.UTC)},
+		{ID: 5, Up: true, In: true, UpFrom: 24649, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 34, 12, 11058000, time.UTC)},
+		{ID: 6, Up: true, In: true, UpFrom: 24764, DownAt: 24758, DownStamp: time.Date(2024, 4, 22, 21, 33, 23, 623053000, time.UTC)},
+		{ID: 7, Up: true, In: true, UpFrom: 24762, DownAt: 24757, DownStamp: time.Date(2024, 4, 22, 21, 33, 7, 530156000, time.UTC)},
+		{ID: 8, Up: true, In: true, UpFrom: 24766, DownAt: 24758, DownStamp: time.Date(2024, 4, 22, 21, 33, 26, 55929000, time.UTC)},
+		{ID: 9, Up: true, In: true, UpFrom: 24837, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 19, 23, 51, 209805000, time.UTC)},
+		{ID: 10, Up: true, In: true, UpFrom: 24842, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 21, 32, 47, 630119000, time.UTC)},
+		{ID: 11, Up: true, In: true, UpFrom: 24839, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 19, 25, 29, 369899000, time.UTC)},
+		{ID: 12, Up: true, In: true, UpFrom: 24877, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 3, 936385000, time.UTC)},
+		{ID: 13, Up: true, In: true, UpFrom: 24879, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 16, 38508000, time.UTC)},
+		{ID: 14, Up: true, In: true, UpFrom: 24886, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 26, 55929000, time.UTC)},
+	}
+
 	c := New("testdata/ceph_mock_ClusterReport")
 	rep, err := c.ClusterReport(context.Background())
 	r.NoError(err)
@@ -60,6 +418,10 @@ func TestClusterReport(t *testing.T) {
 		NumOSDsByVersion: map[string]uint16{
 			"18.2.2": 15,
 		},
+		NumDaemonsByVersion: map[string]map[string]uint16{
+			"osd": {"18.2.2": 15},
+			"rgw": {"18.2.2": 5},
+		},
 		NumOSDsByDeviceType: map[string]uint16{
 			"ssd": 15,
 		},
@@ -68,6 +430,8 @@ func TestClusterReport(t *testing.T) {
 		TotalOSDUsedMetaKB: uint64(450_830_044),
 		TotalOSDUsedOMAPKB: uint64(5_881_251),
 		NumPools:           14,
+		Pools:              pools,
+		CRUSHMap:           crushMap,
 		NumPGs:             234,
 		NumPGsByState: map[string]uint32{
 			"active": 234,
@@ -77,199 +441,318 @@ func TestClusterReport(t *testing.T) {
 		NearfullRatio:          0.85,
 		BackfillfullRatio:      0.9,
 		FullRatio:              0.95,
-		RequireMinCompatClient: "luminous",
+		RequireMinCompatClient: "reef",
+		RequireOSDRelease:      "reef",
+		MinCompatClient:        "luminous",
+		OSDFlags: models.CephOSDFlags{
+			Cluster:       []string{},
+			OSDs:          map[string][]string{},
+			CRUSHNodes:    map[string][]string{},
+			DeviceClasses: map[string][]string{},
+		},
+		OSDStates: osdStates,
 		OSDDaemons: []models.OSDDaemon{
 			{
-				ID:               0,
-				Hostname:         "nuc01",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.201",
-				BackIP:           "192.168.2.231",
-				MemoryTotalBytes: 65414276,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                 0,
+				Hostname:           "nuc01",
+				Architecture:       "x86_64",
+				KernelVersion:      "6.8.4-2-pve",
+				Distro:             "debian",
+				DistroVersion:      "12",
+				FrontIP:            "192.168.1.201",
+				BackIP:             "192.168.2.231",
+				HeartbeatFrontIP:   "192.168.1.201",
+				HeartbeatBackIP:    "192.168.2.231",
+				CapacityBytes:      892824715264,
+				MemoryTotalBytes:   66984218624,
+				SwapTotalBytes:     0,
+				IsRotational:       false,
+				NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+				NUMAUnknownDevices: []string{"sda"},
 				Devices: []string{
 					"sda",
 				},
 			},
 			{
-				ID:               1,
-				Hostname:         "nuc01",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.201",
-				BackIP:           "192.168.2.231",
-				MemoryTotalBytes: 65414276,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                1,
+				Hostname:          "nuc01",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.201",
+				BackIP:            "192.168.2.231",
+				HeartbeatFrontIP:  "192.168.1.201",
+				HeartbeatBackIP:   "192.168.2.231",
+				CapacityBytes:     1839328133120,
+				MemoryTotalBytes:  66984218624,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               2,
-				Hostname:         "nuc01",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.201",
-				BackIP:           "192.168.2.231",
-				MemoryTotalBytes: 65414276,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                2,
+				Hostname:          "nuc01",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.201",
+				BackIP:            "192.168.2.231",
+				HeartbeatFrontIP:  "192.168.1.201",
+				HeartbeatBackIP:   "192.168.2.231",
+				CapacityBytes:     1839332327424,
+				MemoryTotalBytes:  66984218624,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               3,
-				Hostname:         "nuc02",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.202",
-				BackIP:           "192.168.2.232",
-				MemoryTotalBytes: 65414420,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                 3,
+				Hostname:           "nuc02",
+				Architecture:       "x86_64",
+				KernelVersion:      "6.8.4-2-pve",
+				Distro:             "debian",
+				DistroVersion:      "12",
+				FrontIP:            "192.168.1.202",
+				BackIP:             "192.168.2.232",
+				HeartbeatFrontIP:   "192.168.1.202",
+				HeartbeatBackIP:    "192.168.2.232",
+				CapacityBytes:      892824715264,
+				MemoryTotalBytes:   66984366080,
+				SwapTotalBytes:     0,
+				IsRotational:       false,
+				NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+				NUMAUnknownDevices: []string{"sda"},
 				Devices: []string{
 					"sda",
 				},
 			},
 			{
-				ID:               4,
-				Hostname:         "nuc02",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.202",
-				BackIP:           "192.168.2.232",
-				MemoryTotalBytes: 65414420,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                4,
+				Hostname:          "nuc02",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.202",
+				BackIP:            "192.168.2.232",
+				HeartbeatFrontIP:  "192.168.1.202",
+				HeartbeatBackIP:   "192.168.2.232",
+				CapacityBytes:     1839328133120,
+				MemoryTotalBytes:  66984366080,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               5,
-				Hostname:         "nuc02",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.202",
-				BackIP:           "192.168.2.232",
-				MemoryTotalBytes: 65414420,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                5,
+				Hostname:          "nuc02",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.202",
+				BackIP:            "192.168.2.232",
+				HeartbeatFrontIP:  "192.168.1.202",
+				HeartbeatBackIP:   "192.168.2.232",
+				CapacityBytes:     1839332327424,
+				MemoryTotalBytes:  66984366080,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               6,
-				Hostname:         "nuc03",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.203",
-				BackIP:           "192.168.2.233",
-				MemoryTotalBytes: 65414424,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                 6,
+				Hostname:           "nuc03",
+				Architecture:       "x86_64",
+				KernelVersion:      "6.8.4-2-pve",
+				Distro:             "debian",
+				DistroVersion:      "12",
+				FrontIP:            "192.168.1.203",
+				BackIP:             "192.168.2.233",
+				HeartbeatFrontIP:   "192.168.1.203",
+				HeartbeatBackIP:    "192.168.2.233",
+				CapacityBytes:      892824715264,
+				MemoryTotalBytes:   66984370176,
+				SwapTotalBytes:     0,
+				IsRotational:       false,
+				NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+				NUMAUnknownDevices: []string{"sda"},
 				Devices: []string{
 					"sda",
 				},
 			},
 			{
-				ID:               7,
-				Hostname:         "nuc03",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.203",
-				BackIP:           "192.168.2.233",
-				MemoryTotalBytes: 65414424,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                7,
+				Hostname:          "nuc03",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.203",
+				BackIP:            "192.168.2.233",
+				HeartbeatFrontIP:  "192.168.1.203",
+				HeartbeatBackIP:   "192.168.2.233",
+				CapacityBytes:     1839328133120,
+				MemoryTotalBytes:  66984370176,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               8,
-				Hostname:         "nuc03",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.203",
-				BackIP:           "192.168.2.233",
-				MemoryTotalBytes: 65414424,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                8,
+				Hostname:          "nuc03",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.203",
+				BackIP:            "192.168.2.233",
+				HeartbeatFrontIP:  "192.168.1.203",
+				HeartbeatBackIP:   "192.168.2.233",
+				CapacityBytes:     1839332327424,
+				MemoryTotalBytes:  66984370176,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               9,
-				Hostname:         "nuc04",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.204",
-				BackIP:           "192.168.2.234",
-				MemoryTotalBytes: 65414428,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                 9,
+				Hostname:           "nuc04",
+				Architecture:       "x86_64",
+				KernelVersion:      "6.8.4-2-pve",
+				Distro:             "debian",
+				DistroVersion:      "12",
+				FrontIP:            "192.168.1.204",
+				BackIP:             "192.168.2.234",
+				HeartbeatFrontIP:   "192.168.1.204",
+				HeartbeatBackIP:    "192.168.2.234",
+				CapacityBytes:      892824715264,
+				MemoryTotalBytes:   66984374272,
+				SwapTotalBytes:     0,
+				IsRotational:       false,
+				NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+				NUMAUnknownDevices: []string{"sda"},
 				Devices: []string{
 					"sda",
 				},
 			},
 			{
-				ID:               10,
-				Hostname:         "nuc04",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.204",
-				BackIP:           "192.168.2.234",
-				MemoryTotalBytes: 65414428,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                10,
+				Hostname:          "nuc04",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.204",
+				BackIP:            "192.168.2.234",
+				HeartbeatFrontIP:  "192.168.1.204",
+				HeartbeatBackIP:   "192.168.2.234",
+				CapacityBytes:     1839328133120,
+				MemoryTotalBytes:  66984374272,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               11,
-				Hostname:         "nuc04",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.204",
-				BackIP:           "192.168.2.234",
-				MemoryTotalBytes: 65414428,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                11,
+				Hostname:          "nuc04",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.204",
+				BackIP:            "192.168.2.234",
+				HeartbeatFrontIP:  "192.168.1.204",
+				HeartbeatBackIP:   "192.168.2.234",
+				CapacityBytes:     1839332327424,
+				MemoryTotalBytes:  66984374272,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               12,
-				Hostname:         "nuc05",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.205",
-				BackIP:           "192.168.2.235",
-				MemoryTotalBytes: 65414436,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                 12,
+				Hostname:           "nuc05",
+				Architecture:       "x86_64",
+				KernelVersion:      "6.8.4-2-pve",
+				Distro:             "debian",
+				DistroVersion:      "12",
+				FrontIP:            "192.168.1.205",
+				BackIP:             "192.168.2.235",
+				HeartbeatFrontIP:   "192.168.1.205",
+				HeartbeatBackIP:    "192.168.2.235",
+				CapacityBytes:      892824715264,
+				MemoryTotalBytes:   66984382464,
+				SwapTotalBytes:     0,
+				IsRotational:       false,
+				NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+				NUMAUnknownDevices: []string{"sda"},
 				Devices: []string{
 					"sda",
 				},
 			},
 			{
-				ID:               13,
-				Hostname:         "nuc05",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.205",
-				BackIP:           "192.168.2.235",
-				MemoryTotalBytes: 65414436,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                13,
+				Hostname:          "nuc05",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.205",
+				BackIP:            "192.168.2.235",
+				HeartbeatFrontIP:  "192.168.1.205",
+				HeartbeatBackIP:   "192.168.2.235",
+				CapacityBytes:     1839328133120,
+				MemoryTotalBytes:  66984382464,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
 			},
 			{
-				ID:               14,
-				Hostname:         "nuc05",
-				Architecture:     "x86_64",
-				FrontIP:          "192.168.1.205",
-				BackIP:           "192.168.2.235",
-				MemoryTotalBytes: 65414436,
-				SwapTotalBytes:   0,
-				IsRotational:     false,
+				ID:                14,
+				Hostname:          "nuc05",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.4-2-pve",
+				Distro:            "debian",
+				DistroVersion:     "12",
+				FrontIP:           "192.168.1.205",
+				BackIP:            "192.168.2.235",
+				HeartbeatFrontIP:  "192.168.1.205",
+				HeartbeatBackIP:   "192.168.2.235",
+				CapacityBytes:     1839332327424,
+				MemoryTotalBytes:  66984382464,
+				SwapTotalBytes:    0,
+				IsRotational:      false,
+				NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 				Devices: []string{
 					"nvme0n1",
 				},
@@ -305,9 +788,36 @@ func TestClusterStatus(t *testing.T) {
 		MGRsDownAmount: 0,
 		MDSsDownAmount: 0,
 		OSDsDownAmount: 0,
+
+		ClientReadBytesPerSec:  15184,
+		ClientWriteBytesPerSec: 34975,
+		ClientReadOpsPerSec:    16,
+		ClientWriteOpsPerSec:   22,
 	}, st)
 }
 
+func TestDaemonVersions(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_DaemonVersions")
+	versions, err := c.DaemonVersions(context.Background())
+	r.NoError(err)
+	r.Equal(map[string]map[string]uint16{
+		"mon": {"18.2.2": 5},
+		"mgr": {"18.2.2": 2},
+		"osd": {"18.2.1": 3, "18.2.2": 12},
+		"mds": {"18.2.2": 2},
+	}, versions)
+}
+
+func TestDisableMgrModule(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_DisableMgrModule")
+	err := c.DisableMgrModule(context.Background(), "restful")
+	r.NoError(err)
+}
+
 func TestDumpConfig(t *testing.T) {
 	r := require.New(t)
 
@@ -318,9 +828,91 @@ func TestDumpConfig(t *testing.T) {
 		"client.radosgw": {
 			"rgw_cache_lru_size": "100000",
 		},
+		"osd": {
+			"osd_memory_target": "4294967296",
+		},
+		"osd/host:nuc01": {
+			"osd_memory_target": "8589934592",
+		},
+		"osd/class:hdd": {
+			"osd_recovery_sleep": "0.100000",
+		},
 	}, cfg)
 }
 
+func TestEnableMgrModule(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_EnableMgrModule")
+	err := c.EnableMgrModule(context.Background(), "prometheus")
+	r.NoError(err)
+}
+
+func TestGetMaintenanceLease(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_GetMaintenanceLease")
+	lease, err := c.GetMaintenanceLease(context.Background())
+	r.NoError(err)
+	r.Equal(&models.MaintenanceLease{
+		Who: "nuc01",
+		Flags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			CRUSHNodes: map[string][]string{
+				"nuc01": {"noout"},
+			},
+		},
+		StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC),
+	}, lease)
+}
+
+func TestGetMaintenanceLeaseEmpty(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_GetMaintenanceLeaseEmpty")
+	lease, err := c.GetMaintenanceLease(context.Background())
+	r.NoError(err)
+	r.Nil(lease)
+}
+
+func TestListAuthEntities(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_ListAuthEntities")
+	entities, err := c.ListAuthEntities(context.Background())
+	r.NoError(err)
+	r.Equal([]models.AuthEntity{
+		{
+			Name: "osd.0",
+			Key:  "AQBp1kJmAAAAABAAu2GeNvdeV4d0jCBQvVz4Dw==",
+			Caps: map[string]string{
+				"mgr": "allow profile osd",
+				"mon": "allow profile osd",
+				"osd": "allow *",
+			},
+		},
+		{
+			Name: "client.admin",
+			Key:  "AQBf1kJmAAAAABAA6ajB2Cg4ZWo2Zq1M3uNuAw==",
+			Caps: map[string]string{
+				"mds": "allow *",
+				"mgr": "allow *",
+				"mon": "allow *",
+				"osd": "allow *",
+			},
+		},
+		{
+			Name: "client.rbd-prod",
+			Key:  "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+			},
+		},
+	}, entities)
+}
+
 func TestListDevices(t *testing.T) {
 	r := require.New(t)
 	c := New("testdata/ceph_mock_ListDevices")
@@ -345,6 +937,144 @@ func TestListDevices(t *testing.T) {
 	}, devices)
 }
 
+func TestListFileSystems(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_ListFileSystems")
+	filesystems, err := c.ListFileSystems(context.Background())
+	r.NoError(err)
+	r.Equal([]models.FileSystem{
+		{
+			Name:               "shared-data",
+			MaxMDS:             1,
+			AllowStandbyReplay: false,
+			SessionTimeout:     60,
+			MaxFileSize:        1099511627776,
+			Joinable:           true,
+		},
+	}, filesystems)
+}
+
+func TestListMgrModules(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_ListMgrModules")
+	modules, err := c.ListMgrModules(context.Background())
+	r.NoError(err)
+	r.Equal(models.MgrModules{
+		AlwaysOn: []string{
+			"balancer", "crash", "devicehealth", "orchestrator", "pg_autoscaler",
+			"progress", "rbd_support", "status", "telemetry", "volumes",
+		},
+		Enabled:  []string{"iostat", "nfs", "prometheus", "restful"},
+		Disabled: []string{"alerts", "dashboard", "influx", "zabbix"},
+	}, modules)
+}
+
+func TestListOSDUsage(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_ListOSDUsage")
+	usage, err := c.ListOSDUsage(context.Background())
+	r.NoError(err)
+	r.Equal([]models.OSDUsage{
+		{
+			ID:          0,
+			DeviceClass: "ssd",
+			CapacityKB:  871899136,
+			UsedKB:      446237500,
+			AvailableKB: 425661636,
+			Utilization: 0.5117967801196255,
+			Reweight:    1,
+			NumPGs:      72,
+			Status:      "up",
+		},
+		{
+			ID:          1,
+			DeviceClass: "nvme",
+			CapacityKB:  1796218880,
+			UsedKB:      948012544,
+			AvailableKB: 848206336,
+			Utilization: 0.5277844624922643,
+			Reweight:    1,
+			NumPGs:      153,
+			Status:      "up",
+		},
+		{
+			ID:          2,
+			DeviceClass: "nvme",
+			Status:      "down",
+		},
+	}, usage)
+}
+
+func TestMuteHealthCheck(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_MuteHealthCheck")
+	err := c.MuteHealthCheck(context.Background(), "OSDMAP_FLAGS", 4*time.Hour, true)
+	r.NoError(err)
+
+	err = c.MuteHealthCheck(context.Background(), "POOL_NO_REDUNDANCY", 0, false)
+	r.NoError(err)
+}
+
+func TestOSDOkToStop(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_OSDOkToStop")
+	out, err := c.OSDOkToStop(context.Background(), []uint16{0, 1})
+	r.NoError(err)
+	r.Equal(models.OSDOkToStop{
+		OkToStop:          true,
+		OSDs:              []uint16{0, 1},
+		NumOkPGs:          42,
+		NumNotOkPGs:       0,
+		BadBecomeInactive: []string{},
+		OkBecomeDegraded:  []string{"2.1", "2.7"},
+	}, out)
+
+	out, err = c.OSDOkToStop(context.Background(), []uint16{2, 3})
+	r.NoError(err)
+	r.Equal(models.OSDOkToStop{
+		OkToStop:          false,
+		OSDs:              []uint16{2, 3},
+		NumOkPGs:          40,
+		NumNotOkPGs:       2,
+		BadBecomeInactive: []string{"2.3", "2.5"},
+		OkBecomeDegraded:  []string{},
+	}, out)
+
+	_, err = c.OSDOkToStop(context.Background(), []uint16{5})
+	r.Error(err)
+}
+
+func TestOSDSafeToDestroy(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_OSDSafeToDestroy")
+	out, err := c.OSDSafeToDestroy(context.Background(), []uint16{0, 1})
+	r.NoError(err)
+	r.Equal(models.OSDSafeToDestroy{
+		SafeToDestroy: []uint16{},
+		Active:        []uint16{0, 1},
+		MissingStats:  []uint16{},
+		StoredPGs:     []uint16{0, 1},
+	}, out)
+
+	out, err = c.OSDSafeToDestroy(context.Background(), []uint16{2})
+	r.NoError(err)
+	r.Equal(models.OSDSafeToDestroy{
+		SafeToDestroy: []uint16{2},
+		Active:        []uint16{},
+		MissingStats:  []uint16{},
+		StoredPGs:     []uint16{},
+	}, out)
+
+	_, err = c.OSDSafeToDestroy(context.Background(), []uint16{5})
+	r.Error(err)
+}
+
 func TestRemoveCephConfigOption(t *testing.T) {
 	r := require.New(t)
 
@@ -352,3 +1082,104 @@ func TestRemoveCephConfigOption(t *testing.T) {
 	err := c.RemoveCephConfigOption(context.Background(), "section", "key")
 	r.NoError(err)
 }
+
+func TestRemoveMaintenanceLease(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_RemoveMaintenanceLease")
+	err := c.RemoveMaintenanceLease(context.Background())
+	r.NoError(err)
+}
+
+func TestSetAuthCaps(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetAuthCaps")
+	err := c.SetAuthCaps(context.Background(), "client.rbd-prod", map[string]string{
+		"osd": "profile rbd pool=rbd",
+		"mon": "profile rbd",
+		"mgr": "profile rbd pool=rbd",
+	})
+	r.NoError(err)
+}
+
+func TestSetBalancerActive(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetBalancerActive")
+	err := c.SetBalancerActive(context.Background(), true)
+	r.NoError(err)
+
+	err = c.SetBalancerActive(context.Background(), false)
+	r.NoError(err)
+}
+
+func TestSetBalancerMode(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetBalancerMode")
+	err := c.SetBalancerMode(context.Background(), "upmap")
+	r.NoError(err)
+}
+
+func TestSetFileSystemOption(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetFileSystemOption")
+	err := c.SetFileSystemOption(context.Background(), "shared-data", "max_mds", "2")
+	r.NoError(err)
+}
+
+func TestSetMaintenanceLease(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetMaintenanceLease")
+	err := c.SetMaintenanceLease(context.Background(), models.MaintenanceLease{
+		Who: "osd.3",
+		Flags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			OSDs: map[string][]string{
+				"osd.3": {"noout"},
+			},
+		},
+		StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC),
+	})
+	r.NoError(err)
+}
+
+func TestSetOSDFlag(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_SetOSDFlag")
+	err := c.SetOSDFlag(context.Background(), "noout", "")
+	r.NoError(err)
+
+	err = c.SetOSDFlag(context.Background(), "noout", "osd.3")
+	r.NoError(err)
+
+	err = c.SetOSDFlag(context.Background(), "noin", "")
+	r.Error(err)
+}
+
+func TestUnmuteHealthCheck(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_UnmuteHealthCheck")
+	err := c.UnmuteHealthCheck(context.Background(), "OSDMAP_FLAGS")
+	r.NoError(err)
+}
+
+func TestUnsetOSDFlag(t *testing.T) {
+	r := require.New(t)
+
+	c := New("testdata/ceph_mock_UnsetOSDFlag")
+	err := c.UnsetOSDFlag(context.Background(), "noout", "")
+	r.NoError(err)
+
+	err = c.UnsetOSDFlag(context.Background(), "noout", "osd.3")
+	r.NoError(err)
+
+	err = c.UnsetOSDFlag(context.Background(), "noin", "")
+	r.Error(err)
+}
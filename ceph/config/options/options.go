@@ -0,0 +1,179 @@
+// Package options provides Ceph configuration option metadata (schema)
+// allowing to validate CephConfig specifications offline.
+//
+// Schema files are bundled per Ceph release and have the following format:
+//
+//	{
+//	  "release": "reef",
+//	  "options": [
+//	    {
+//	      "name": "osd_scrub_begin_hour",
+//	      "type": "int",
+//	      "level": "advanced",
+//	      "services": ["osd"],
+//	      "default": 0,
+//	      "min": 0,
+//	      "max": 23,
+//	      "can_update_at_runtime": true
+//	    }
+//	  ]
+//	}
+//
+// which is a subset of `ceph config help <option> --format=json` output
+// with numeric min/max values (sizes are in bytes, durations are in seconds).
+//
+// Bundled schemas cover the commonly used options only, so the option missing
+// from the schema is not necessarily unknown to Ceph. Use the schema file
+// generated from `ceph config ls` and `ceph config help` output of the
+// particular cluster for complete validation.
+package options
+
+import (
+	"embed"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed data/*.json
+var bundled embed.FS
+
+var ErrUnknownRelease = errors.New("unknown Ceph release")
+
+type Type string
+
+const (
+	TypeAddr      Type = "addr"
+	TypeAddrVec   Type = "addrvec"
+	TypeBool      Type = "bool"
+	TypeFloat     Type = "float"
+	TypeInt       Type = "int"
+	TypeMillisecs Type = "millisecs"
+	TypeSecs      Type = "secs"
+	TypeSize      Type = "size"
+	TypeStr       Type = "str"
+	TypeUInt      Type = "uint"
+	TypeUUID      Type = "uuid"
+)
+
+type Option struct {
+	Name               string   `json:"name"`
+	Type               Type     `json:"type"`
+	Level              string   `json:"level"`
+	Services           []string `json:"services"`
+	Default            any      `json:"default,omitempty"`
+	Min                *float64 `json:"min,omitempty"`
+	Max                *float64 `json:"max,omitempty"`
+	EnumValues         []string `json:"enum_values,omitempty"`
+	CanUpdateAtRuntime bool     `json:"can_update_at_runtime"`
+}
+
+type Schema struct {
+	Release string   `json:"release"`
+	Options []Option `json:"options"`
+
+	index map[string]Option
+}
+
+// Releases returns the list of Ceph releases with bundled schema
+func Releases() ([]string, error) {
+	entries, err := bundled.ReadDir("data")
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing bundled schemas")
+	}
+
+	releases := []string{}
+	for _, e := range entries {
+		releases = append(releases, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	slices.Sort(releases)
+
+	return releases, nil
+}
+
+// Load returns bundled schema for the specified Ceph release
+func Load(release string) (*Schema, error) {
+	data, err := bundled.ReadFile("data/" + strings.ToLower(release) + ".json")
+	if err != nil {
+		releases, lerr := Releases()
+		if lerr != nil {
+			return nil, lerr
+		}
+		return nil, errors.Wrapf(ErrUnknownRelease, "`%s` (available: %s)", release, strings.Join(releases, ", "))
+	}
+
+	return New(data)
+}
+
+// LoadFile returns schema read from the file
+func LoadFile(filename string) (*Schema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option schema file")
+	}
+
+	return New(data)
+}
+
+func New(in []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(in, s); err != nil {
+		return nil, errors.Wrap(err, "error decoding option schema")
+	}
+
+	s.index = make(map[string]Option, len(s.Options))
+	for _, opt := range s.Options {
+		s.index[opt.Name] = opt
+	}
+
+	return s, nil
+}
+
+// Lookup returns option metadata by its name
+func (s *Schema) Lookup(name string) (Option, bool) {
+	opt, ok := s.index[name]
+	return opt, ok
+}
+
+// Suggest returns the closest known option name to the given one
+// or empty string if there's no similar option
+func (s *Schema) Suggest(name string) string {
+	const maxDistance = 2
+
+	suggestion := ""
+	best := maxDistance + 1
+	for _, opt := range s.Options {
+		d := levenshtein(name, opt.Name)
+		if d < best {
+			best = d
+			suggestion = opt.Name
+		}
+	}
+
+	return suggestion
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,72 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	r := require.New(t)
+
+	releases, err := Releases()
+	r.NoError(err)
+	r.Equal([]string{"reef"}, releases)
+
+	for _, release := range releases {
+		s, err := Load(release)
+		r.NoError(err)
+		r.Equal(release, s.Release)
+
+		opt, ok := s.Lookup("osd_max_backfills")
+		r.True(ok)
+		r.Equal(TypeUInt, opt.Type)
+		r.Equal([]string{"osd"}, opt.Services)
+	}
+}
+
+func TestLoadUnknownRelease(t *testing.T) {
+	r := require.New(t)
+
+	_, err := Load("argonaut")
+	r.Error(err)
+	r.True(errors.Is(err, ErrUnknownRelease))
+	r.Equal("`argonaut` (available: reef): unknown Ceph release", err.Error())
+}
+
+func TestLoadFile(t *testing.T) {
+	r := require.New(t)
+
+	s, err := LoadFile("testdata/schema.json")
+	r.NoError(err)
+	r.Equal("custom", s.Release)
+	r.Len(s.Options, 1)
+
+	_, ok := s.Lookup("osd_max_backfills")
+	r.True(ok)
+
+	_, ok = s.Lookup("osd_memory_target")
+	r.False(ok)
+}
+
+func TestSuggest(t *testing.T) {
+	r := require.New(t)
+
+	s, err := Load("reef")
+	r.NoError(err)
+
+	r.Equal("osd_max_backfills", s.Suggest("osd_max_backfils"))
+	r.Equal("osd_max_backfills", s.Suggest("osd_max_backfillz"))
+	r.Equal("", s.Suggest("completely_different_option"))
+}
+
+func TestLevenshtein(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(0, levenshtein("", ""))
+	r.Equal(3, levenshtein("abc", ""))
+	r.Equal(3, levenshtein("", "abc"))
+	r.Equal(1, levenshtein("abc", "abd"))
+	r.Equal(3, levenshtein("kitten", "sitting"))
+}
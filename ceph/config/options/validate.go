@@ -0,0 +1,184 @@
+package options
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/ceph/config/spec/cephconfig"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Issue struct {
+	Location spec.Location
+	Severity Severity
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Location, i.Severity, i.Message)
+}
+
+var (
+	daemonTypes = []string{"mon", "mgr", "osd", "mds", "client"}
+	maskTypes   = []string{
+		"class", "host", "chassis", "rack", "row", "pdu", "pod", "room",
+		"datacenter", "zone", "region", "root",
+	}
+
+	sectionRe = regexp.MustCompile(`^(global|mon|mgr|osd|mds|client)(\.[A-Za-z0-9_.\-]+)?$`)
+)
+
+// Validate checks all of the CephConfig specifications against the schema
+func (s *Schema) Validate(descs []spec.Description) ([]Issue, error) {
+	issues := []Issue{}
+	for _, desc := range descs {
+		if strings.ToLower(desc.Kind) != "cephconfig" {
+			continue
+		}
+
+		is, err := s.ValidateSpec(desc)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, is...)
+	}
+
+	return issues, nil
+}
+
+// ValidateSpec checks CephConfig specification against the schema and returns
+// the list of issues found each pointing to the particular location within
+// specification file.
+func (s *Schema) ValidateSpec(desc spec.Description) ([]Issue, error) {
+	cfg, err := cephconfig.New(desc.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []string{}
+	for section := range cfg {
+		sections = append(sections, section)
+	}
+	slices.Sort(sections)
+
+	issues := []Issue{}
+	for _, section := range sections {
+		daemonType, err := validateSection(section)
+		if err != nil {
+			issues = append(issues, Issue{
+				Location: desc.Origin.Locate(section),
+				Severity: SeverityError,
+				Message:  err.Error(),
+			})
+			continue
+		}
+
+		keys := []string{}
+		for key := range cfg[section] {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			if issue, ok := s.validateOption(daemonType, key, cfg[section][key]); ok {
+				issue.Location = desc.Origin.Locate(section, key)
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+func (s *Schema) validateOption(daemonType, name, value string) (Issue, bool) {
+	// Manager module options (i.e. mgr/dashboard/server_port) are defined
+	// by the modules themselves and are not the part of the schema
+	if strings.Contains(name, "/") {
+		return Issue{}, false
+	}
+
+	opt, ok := s.Lookup(name)
+	if !ok {
+		if suggestion := s.Suggest(name); suggestion != "" {
+			return Issue{
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("unknown option `%s`, did you mean `%s`?", name, suggestion),
+			}, true
+		}
+
+		return Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("option `%s` is not known for %s release", name, s.Release),
+		}, true
+	}
+
+	if err := opt.ValidateValue(value); err != nil {
+		return Issue{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("invalid value for `%s` (%s): %s", name, opt.Type, err),
+		}, true
+	}
+
+	if daemonType != "" && !appliesTo(opt, daemonType) {
+		return Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("option `%s` is not used by %s daemons (used by: %s)", name, daemonType, strings.Join(opt.Services, ", ")),
+		}, true
+	}
+
+	return Issue{}, false
+}
+
+// validateSection checks section name (and optional mask) and returns
+// daemon type the section is related to or empty string for global section
+func validateSection(section string) (string, error) {
+	who, mask, hasMask := strings.Cut(section, "/")
+
+	m := sectionRe.FindStringSubmatch(who)
+	if m == nil || (m[1] == "global" && m[2] != "") {
+		return "", errors.Errorf("unexpected section name `%s`: expected one of global, %s optionally followed by daemon id", who, strings.Join(daemonTypes, ", "))
+	}
+
+	if hasMask {
+		maskType, maskValue, ok := strings.Cut(mask, ":")
+		if !ok || maskValue == "" || !slices.Contains(maskTypes, maskType) {
+			return "", errors.Errorf("unexpected mask `%s`: expected <type>:<value> where type is one of %s", mask, strings.Join(maskTypes, ", "))
+		}
+	}
+
+	if m[1] == "global" {
+		return "", nil
+	}
+	return m[1], nil
+}
+
+func appliesTo(opt Option, daemonType string) bool {
+	if len(opt.Services) == 0 {
+		return true
+	}
+
+	for _, svc := range opt.Services {
+		switch svc {
+		case "common", daemonType:
+			return true
+		case "rgw", "rbd", "rbd-mirror", "cephfs-mirror", "mds_client":
+			if daemonType == "client" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+)
+
+func TestValidate(t *testing.T) {
+	r := require.New(t)
+
+	s, err := Load("reef")
+	r.NoError(err)
+
+	descs, err := spec.NewFromDescription("testdata/cephconfig.yaml")
+	r.NoError(err)
+
+	issues, err := s.Validate(descs)
+	r.NoError(err)
+
+	out := []string{}
+	for _, issue := range issues {
+		out = append(out, issue.String())
+	}
+
+	r.Equal([]string{
+		"testdata/cephconfig.yaml:6:5: error: invalid value for `mon_allow_pool_delete` (bool): `maybe` is not a valid boolean value",
+		"testdata/cephconfig.yaml:16:5: warning: option `osd_max_backfills` is not used by mon daemons (used by: osd)",
+		"testdata/cephconfig.yaml:8:5: error: unknown option `osd_max_backfils`, did you mean `osd_max_backfills`?",
+		"testdata/cephconfig.yaml:9:5: error: invalid value for `osd_scrub_begin_hour` (int): `25` is greater than maximal allowed value 23",
+		"testdata/cephconfig.yaml:11:5: warning: option `some_unknown_option_name` is not known for reef release",
+		"testdata/cephconfig.yaml:17:3: error: unexpected mask `unknown:ssd`: expected <type>:<value> where type is one of class, host, chassis, rack, row, pdu, pod, room, datacenter, zone, region, root",
+	}, out)
+}
+
+func TestValidateSection(t *testing.T) {
+	type testCase struct {
+		name          string
+		section       string
+		expDaemonType string
+		expError      bool
+	}
+
+	tcs := []testCase{
+		{name: "global", section: "global", expDaemonType: ""},
+		{name: "daemon type", section: "osd", expDaemonType: "osd"},
+		{name: "daemon", section: "osd.3", expDaemonType: "osd"},
+		{name: "client", section: "client.rgw.rgw01", expDaemonType: "client"},
+		{name: "host mask", section: "osd/host:nuc01", expDaemonType: "osd"},
+		{name: "class mask", section: "osd/class:ssd", expDaemonType: "osd"},
+		{name: "global with id", section: "global.1", expError: true},
+		{name: "unknown daemon type", section: "rgw", expError: true},
+		{name: "empty mask value", section: "osd/host:", expError: true},
+		{name: "malformed mask", section: "osd/host", expError: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			daemonType, err := validateSection(tc.section)
+			if tc.expError {
+				r.Error(err)
+			} else {
+				r.NoError(err)
+				r.Equal(tc.expDaemonType, daemonType)
+			}
+		})
+	}
+}
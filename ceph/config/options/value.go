@@ -0,0 +1,139 @@
+package options
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateValue checks the value against option type, enum values and limits
+func (o Option) ValidateValue(value string) error {
+	var (
+		v   float64
+		err error
+	)
+
+	switch o.Type {
+	case TypeBool:
+		switch strings.ToLower(value) {
+		case "true", "false", "yes", "no", "1", "0":
+			return nil
+		}
+		return errors.Errorf("`%s` is not a valid boolean value", value)
+
+	case TypeStr:
+		if len(o.EnumValues) > 0 && !slices.Contains(o.EnumValues, value) {
+			return errors.Errorf("`%s` is not one of allowed values: %s", value, strings.Join(o.EnumValues, ", "))
+		}
+		return nil
+
+	case TypeUUID:
+		if !uuidRe.MatchString(value) {
+			return errors.Errorf("`%s` is not a valid UUID", value)
+		}
+		return nil
+
+	case TypeAddr, TypeAddrVec:
+		return nil
+
+	case TypeFloat:
+		v, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.Errorf("`%s` is not a valid floating point number", value)
+		}
+
+	case TypeInt, TypeUInt:
+		v, err = parseSuffixed(value, siSuffixes)
+		if err != nil || v != math.Trunc(v) {
+			return errors.Errorf("`%s` is not a valid integer", value)
+		}
+		if o.Type == TypeUInt && v < 0 {
+			return errors.Errorf("`%s` must not be negative", value)
+		}
+
+	case TypeSize:
+		v, err = parseSuffixed(value, iecSuffixes)
+		if err != nil || v < 0 {
+			return errors.Errorf("`%s` is not a valid size", value)
+		}
+
+	case TypeSecs:
+		v, err = parseSuffixed(value, timeSuffixes)
+		if err != nil {
+			return errors.Errorf("`%s` is not a valid duration", value)
+		}
+
+	case TypeMillisecs:
+		v, err = strconv.ParseFloat(value, 64)
+		if err != nil || v != math.Trunc(v) {
+			return errors.Errorf("`%s` is not a valid amount of milliseconds", value)
+		}
+
+	default:
+		return nil
+	}
+
+	if o.Min != nil && v < *o.Min {
+		return errors.Errorf("`%s` is less than minimal allowed value %s", value, formatFloat(*o.Min))
+	}
+
+	if o.Max != nil && v > *o.Max {
+		return errors.Errorf("`%s` is greater than maximal allowed value %s", value, formatFloat(*o.Max))
+	}
+
+	return nil
+}
+
+type suffix struct {
+	suffix     string
+	multiplier float64
+}
+
+// Suffixes are ordered the way the longest ones are matched first
+var (
+	iecSuffixes = []suffix{
+		{"Ei", 1 << 60}, {"Pi", 1 << 50}, {"Ti", 1 << 40}, {"Gi", 1 << 30}, {"Mi", 1 << 20}, {"Ki", 1 << 10},
+		{"E", 1 << 60}, {"P", 1 << 50}, {"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
+	}
+
+	siSuffixes = []suffix{
+		{"E", 1e18}, {"P", 1e15}, {"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"K", 1e3}, {"k", 1e3},
+	}
+
+	timeSuffixes = []suffix{
+		{"weeks", 604800}, {"week", 604800}, {"days", 86400}, {"day", 86400},
+		{"hours", 3600}, {"hour", 3600}, {"mins", 60}, {"min", 60}, {"secs", 1}, {"sec", 1},
+		{"w", 604800}, {"d", 86400}, {"h", 3600}, {"m", 60}, {"s", 1},
+	}
+)
+
+func parseSuffixed(value string, suffixes []suffix) (float64, error) {
+	value = strings.TrimSpace(value)
+
+	multiplier := 1.0
+	for _, s := range suffixes {
+		if strings.HasSuffix(value, s.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, s.suffix))
+			multiplier = s.multiplier
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return v * multiplier, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
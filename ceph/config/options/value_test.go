@@ -0,0 +1,145 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/teran/go-ptr"
+)
+
+func TestValidateValue(t *testing.T) {
+	type testCase struct {
+		name     string
+		option   Option
+		value    string
+		expError string
+	}
+
+	tcs := []testCase{
+		{
+			name:   "bool",
+			option: Option{Type: TypeBool},
+			value:  "true",
+		},
+		{
+			name:     "invalid bool",
+			option:   Option{Type: TypeBool},
+			value:    "maybe",
+			expError: "`maybe` is not a valid boolean value",
+		},
+		{
+			name:   "string",
+			option: Option{Type: TypeStr},
+			value:  "any value",
+		},
+		{
+			name:   "enum",
+			option: Option{Type: TypeStr, EnumValues: []string{"none", "passive"}},
+			value:  "passive",
+		},
+		{
+			name:     "invalid enum",
+			option:   Option{Type: TypeStr, EnumValues: []string{"none", "passive"}},
+			value:    "active",
+			expError: "`active` is not one of allowed values: none, passive",
+		},
+		{
+			name:   "uuid",
+			option: Option{Type: TypeUUID},
+			value:  "efc38fd3-2cb1-4e77-9df2-228eebd3af3d",
+		},
+		{
+			name:     "invalid uuid",
+			option:   Option{Type: TypeUUID},
+			value:    "efc38fd3",
+			expError: "`efc38fd3` is not a valid UUID",
+		},
+		{
+			name:   "int with SI suffix",
+			option: Option{Type: TypeInt},
+			value:  "-1K",
+		},
+		{
+			name:     "invalid int",
+			option:   Option{Type: TypeInt},
+			value:    "1.5",
+			expError: "`1.5` is not a valid integer",
+		},
+		{
+			name:     "negative uint",
+			option:   Option{Type: TypeUInt},
+			value:    "-1",
+			expError: "`-1` must not be negative",
+		},
+		{
+			name:   "int within limits",
+			option: Option{Type: TypeInt, Min: ptr.Float64(0), Max: ptr.Float64(23)},
+			value:  "23",
+		},
+		{
+			name:     "int greater than max",
+			option:   Option{Type: TypeInt, Min: ptr.Float64(0), Max: ptr.Float64(23)},
+			value:    "25",
+			expError: "`25` is greater than maximal allowed value 23",
+		},
+		{
+			name:   "size with IEC suffix",
+			option: Option{Type: TypeSize, Min: ptr.Float64(896 * 1024 * 1024)},
+			value:  "4Gi",
+		},
+		{
+			name:     "size less than min",
+			option:   Option{Type: TypeSize, Min: ptr.Float64(896 * 1024 * 1024)},
+			value:    "512M",
+			expError: "`512M` is less than minimal allowed value 939524096",
+		},
+		{
+			name:     "invalid size",
+			option:   Option{Type: TypeSize},
+			value:    "lots",
+			expError: "`lots` is not a valid size",
+		},
+		{
+			name:   "float",
+			option: Option{Type: TypeFloat, Min: ptr.Float64(0), Max: ptr.Float64(1)},
+			value:  "0.8",
+		},
+		{
+			name:     "invalid float",
+			option:   Option{Type: TypeFloat},
+			value:    "0,8",
+			expError: "`0,8` is not a valid floating point number",
+		},
+		{
+			name:   "secs with suffix",
+			option: Option{Type: TypeSecs},
+			value:  "30m",
+		},
+		{
+			name:     "invalid secs",
+			option:   Option{Type: TypeSecs},
+			value:    "soon",
+			expError: "`soon` is not a valid duration",
+		},
+		{
+			name:     "invalid millisecs",
+			option:   Option{Type: TypeMillisecs},
+			value:    "10ms",
+			expError: "`10ms` is not a valid amount of milliseconds",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			err := tc.option.ValidateValue(tc.value)
+			if tc.expError != "" {
+				r.Error(err)
+				r.Equal(tc.expError, err.Error())
+			} else {
+				r.NoError(err)
+			}
+		})
+	}
+}
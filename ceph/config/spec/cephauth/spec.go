@@ -0,0 +1,17 @@
+package cephauth
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephAuth, error) {
+	spec := models.CephAuth{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephAuth{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
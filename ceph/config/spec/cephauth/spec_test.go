@@ -0,0 +1,45 @@
+package cephauth
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	auth, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+			},
+		},
+		"client.rgw.*": {
+			Caps: map[string]string{
+				"mon": "allow rw",
+				"osd": "allow rwx",
+			},
+		},
+	}, auth)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	auth, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephAuth{}, auth)
+}
@@ -0,0 +1,22 @@
+package cephbalancer
+
+import (
+	"github.com/creasty/defaults"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephBalancer, error) {
+	spec := models.CephBalancer{}
+	if err := defaults.Set(&spec); err != nil {
+		return models.CephBalancer{}, errors.Wrap(err, "error setting default values")
+	}
+
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephBalancer{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,40 @@
+package cephbalancer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	cfg, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephBalancer{
+		Mode:              "crush-compat",
+		Active:            false,
+		UpmapMaxDeviation: 1,
+	}, cfg)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	cfg, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 5,
+	}, cfg)
+}
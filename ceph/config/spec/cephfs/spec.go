@@ -0,0 +1,17 @@
+package cephfs
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephFS, error) {
+	spec := models.CephFS{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephFS{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,44 @@
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/teran/go-ptr"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	fs, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(2),
+			AllowStandbyReplay: ptr.Bool(true),
+			SessionTimeout:     ptr.Uint32(120),
+			MaxFileSize:        ptr.Uint64(2199023255552),
+			Joinable:           ptr.Bool(true),
+		},
+		"backups": {
+			MaxMDS: ptr.Uint16(1),
+		},
+	}, fs)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	fs, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephFS{}, fs)
+}
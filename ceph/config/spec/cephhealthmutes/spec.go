@@ -0,0 +1,17 @@
+package cephhealthmutes
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephHealthMutes, error) {
+	spec := models.CephHealthMutes{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephHealthMutes{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,42 @@
+package cephhealthmutes
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	mutes, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephHealthMutes{
+		"OSDMAP_FLAGS": {
+			TTL:           4 * time.Hour,
+			Sticky:        true,
+			Justification: "maintenance of nuc01",
+		},
+		"POOL_NO_REDUNDANCY": {
+			Justification: "test pool",
+		},
+	}, mutes)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	mutes, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephHealthMutes{}, mutes)
+}
@@ -0,0 +1,17 @@
+package cephmgrmodules
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephMgrModules, error) {
+	spec := models.CephMgrModules{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephMgrModules{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
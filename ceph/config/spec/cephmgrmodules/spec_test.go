@@ -0,0 +1,35 @@
+package cephmgrmodules
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	modules, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephMgrModules{
+		Enabled:  []string{"prometheus", "pg_autoscaler"},
+		Disabled: []string{"dashboard", "restful"},
+	}, modules)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	modules, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephMgrModules{}, modules)
+}
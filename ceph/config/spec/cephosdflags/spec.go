@@ -0,0 +1,17 @@
+package cephosdflags
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func New(in []byte) (models.CephOSDFlags, error) {
+	spec := models.CephOSDFlags{}
+	if err := yaml.Unmarshal(in, &spec); err != nil {
+		return models.CephOSDFlags{}, errors.Wrap(err, "error decoding spec file")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,43 @@
+package cephosdflags
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewValidConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/full.json")
+	r.NoError(err)
+
+	flags, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephOSDFlags{
+		Cluster: []string{"noscrub", "nodeep-scrub"},
+		OSDs: map[string][]string{
+			"osd.3": {"noout"},
+		},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout", "noin"},
+		},
+		DeviceClasses: map[string][]string{
+			"hdd": {"nodown"},
+		},
+	}, flags)
+}
+
+func TestNewEmptyConfig(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/empty.json")
+	r.NoError(err)
+
+	flags, err := New(data)
+	r.NoError(err)
+	r.Equal(models.CephOSDFlags{}, flags)
+}
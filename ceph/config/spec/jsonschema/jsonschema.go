@@ -0,0 +1,66 @@
+// Package jsonschema implements the subset of JSON Schema (draft 2020-12)
+// required to describe cephctl specifications: schemas are generated from
+// Go types and used both to provide editor completion and to validate
+// specification documents.
+package jsonschema
+
+import (
+	"encoding/json"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	TypeArray   = "array"
+	TypeBoolean = "boolean"
+	TypeInteger = "integer"
+	TypeNull    = "null"
+	TypeNumber  = "number"
+	TypeObject  = "object"
+	TypeString  = "string"
+)
+
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	deny bool
+}
+
+// False returns the schema which never validates (`false` boolean schema),
+// i.e. to deny additional properties
+func False() *Schema {
+	return &Schema{deny: true}
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.deny {
+		return []byte("false"), nil
+	}
+
+	type alias Schema
+	return json.Marshal((*alias)(s))
+}
+
+// Types is the list of allowed JSON types marshaled as a single
+// string when only one type is allowed
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
@@ -0,0 +1,182 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Reflect generates schema from Go type using the following struct tags:
+//
+//   - `yaml` to get property name (fields w/o tag or with `-` are skipped)
+//   - `default` to set default value
+//   - `description` to set property description
+//   - `jsonschema` to set constraints: `minimum=0,maximum=1,enum=a|b`
+//     (constraints of arrays and maps are applied to their items)
+//
+// time.Duration is reflected as string since it's decoded from strings
+// like `4h` or `30m`.
+func Reflect(v any) (*Schema, error) {
+	return reflectType(reflect.TypeOf(v))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func reflectType(t reflect.Type) (*Schema, error) {
+	if t == durationType {
+		return &Schema{Type: Types{TypeString}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflectType(t.Elem())
+
+	case reflect.Bool:
+		return &Schema{Type: Types{TypeBoolean}}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{TypeInteger}}, nil
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{TypeNumber}}, nil
+
+	case reflect.String:
+		return &Schema{Type: Types{TypeString}}, nil
+
+	case reflect.Slice, reflect.Array:
+		items, err := reflectType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{TypeArray}, Items: items}, nil
+
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.Errorf("unsupported map key type: %s", t.Key())
+		}
+
+		values, err := reflectType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{TypeObject}, AdditionalProperties: values}, nil
+
+	case reflect.Struct:
+		return reflectStruct(t)
+	}
+
+	return nil, errors.Errorf("unsupported type: %s", t)
+}
+
+func reflectStruct(t reflect.Type) (*Schema, error) {
+	s := &Schema{
+		Type:                 Types{TypeObject},
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: False(),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		prop, err := reflectType(f.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reflecting field `%s`", f.Name)
+		}
+
+		prop.Description = f.Tag.Get("description")
+
+		if def, ok := f.Tag.Lookup("default"); ok {
+			prop.Default, err = parseValue(prop.Type, def)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing default value for field `%s`", f.Name)
+			}
+		}
+
+		if err := applyConstraints(prop, f.Tag.Get("jsonschema")); err != nil {
+			return nil, errors.Wrapf(err, "error parsing constraints for field `%s`", f.Name)
+		}
+
+		s.Properties[name] = prop
+	}
+
+	return s, nil
+}
+
+func applyConstraints(s *Schema, tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	for {
+		if s.Items != nil {
+			s = s.Items
+		} else if s.AdditionalProperties != nil && !s.AdditionalProperties.deny {
+			s = s.AdditionalProperties
+		} else {
+			break
+		}
+	}
+
+	for _, c := range strings.Split(tag, ",") {
+		k, v, ok := strings.Cut(c, "=")
+		if !ok {
+			return errors.Errorf("malformed constraint `%s`", c)
+		}
+
+		switch k {
+		case "minimum", "maximum":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return errors.Wrapf(err, "error parsing %s", k)
+			}
+
+			if k == "minimum" {
+				s.Minimum = &f
+			} else {
+				s.Maximum = &f
+			}
+
+		case "enum":
+			for _, e := range strings.Split(v, "|") {
+				ev, err := parseValue(s.Type, e)
+				if err != nil {
+					return err
+				}
+				s.Enum = append(s.Enum, ev)
+			}
+
+		default:
+			return errors.Errorf("unexpected constraint `%s`", k)
+		}
+	}
+
+	return nil
+}
+
+func parseValue(types Types, v string) (any, error) {
+	if len(types) != 1 {
+		return v, nil
+	}
+
+	switch types[0] {
+	case TypeBoolean:
+		return strconv.ParseBool(v)
+	case TypeInteger:
+		return strconv.ParseInt(v, 10, 64)
+	case TypeNumber:
+		return strconv.ParseFloat(v, 64)
+	}
+	return v, nil
+}
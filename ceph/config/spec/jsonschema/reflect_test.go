@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testStruct struct {
+	Name    string              `yaml:"name" description:"Name of the thing"`
+	Ratio   float32             `yaml:"ratio" default:"0.5" jsonschema:"minimum=0,maximum=1"`
+	Count   int                 `yaml:"count,omitempty"`
+	Mode    string              `yaml:"mode" default:"on" jsonschema:"enum=on|off"`
+	Tags    []string            `yaml:"tags"`
+	Labels  map[string]string   `yaml:"labels"`
+	Flags   map[string][]string `yaml:"flags" jsonschema:"enum=a|b"`
+	Timeout time.Duration       `yaml:"timeout"`
+	Ignored string              `yaml:"-"`
+	NoTag   string
+}
+
+func TestReflect(t *testing.T) {
+	r := require.New(t)
+
+	s, err := Reflect(testStruct{})
+	r.NoError(err)
+
+	data, err := json.Marshal(s)
+	r.NoError(err)
+	r.JSONEq(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "description": "Name of the thing"},
+			"ratio": {"type": "number", "default": 0.5, "minimum": 0, "maximum": 1},
+			"count": {"type": "integer"},
+			"mode": {"type": "string", "default": "on", "enum": ["on", "off"]},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"flags": {"type": "object", "additionalProperties": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}},
+			"timeout": {"type": "string"}
+		},
+		"additionalProperties": false
+	}`, string(data))
+}
+
+func TestReflectUnsupportedType(t *testing.T) {
+	r := require.New(t)
+
+	_, err := Reflect(map[int]string{})
+	r.Error(err)
+	r.Equal("unsupported map key type: int", err.Error())
+}
@@ -0,0 +1,184 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ValidationError struct {
+	Path    []string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("`%s`: %s", strings.Join(e.Path, "."), e.Message)
+}
+
+// Validate checks the value as it's decoded from YAML or JSON (i.e. into
+// `any`) against the schema and returns all of the errors found
+func (s *Schema) Validate(v any) []ValidationError {
+	return s.validate(v, nil)
+}
+
+func (s *Schema) validate(v any, path []string) []ValidationError {
+	if s.deny {
+		return []ValidationError{{Path: path, Message: "unexpected property"}}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, ss := range s.OneOf {
+			if len(ss.validate(v, path)) == 0 {
+				matched++
+			}
+		}
+
+		if matched != 1 {
+			return []ValidationError{{Path: path, Message: fmt.Sprintf("must match exactly one schema but matches %d", matched)}}
+		}
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return isType(v, t) }) {
+		return []ValidationError{{Path: path, Message: fmt.Sprintf("expected %s but got %s", strings.Join(s.Type, " or "), typeOf(v))}}
+	}
+
+	if s.Const != nil && !equal(s.Const, v) {
+		return []ValidationError{{Path: path, Message: fmt.Sprintf("expected %s but got %s", format(s.Const), format(v))}}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return equal(e, v) }) {
+		allowed := []string{}
+		for _, e := range s.Enum {
+			allowed = append(allowed, format(e))
+		}
+		return []ValidationError{{Path: path, Message: fmt.Sprintf("%s is not one of allowed values: %s", format(v), strings.Join(allowed, ", "))}}
+	}
+
+	if n, ok := toFloat(v); ok {
+		if s.Minimum != nil && n < *s.Minimum {
+			return []ValidationError{{Path: path, Message: fmt.Sprintf("%s is less than minimum %s", format(v), format(*s.Minimum))}}
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return []ValidationError{{Path: path, Message: fmt.Sprintf("%s is greater than maximum %s", format(v), format(*s.Maximum))}}
+		}
+	}
+
+	errs := []ValidationError{}
+	switch val := v.(type) {
+	case map[string]any:
+		for _, r := range s.Required {
+			if _, ok := val[r]; !ok {
+				errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf("missing required property `%s`", r)})
+			}
+		}
+
+		keys := []string{}
+		for k := range val {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			p := append(slices.Clone(path), k)
+			if ps, ok := s.Properties[k]; ok {
+				errs = append(errs, ps.validate(val[k], p)...)
+			} else if s.AdditionalProperties != nil {
+				errs = append(errs, s.AdditionalProperties.validate(val[k], p)...)
+			}
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				errs = append(errs, s.Items.validate(item, append(slices.Clone(path), strconv.Itoa(i)))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func isType(v any, t string) bool {
+	switch t {
+	case TypeNull:
+		return v == nil
+	case TypeBoolean:
+		_, ok := v.(bool)
+		return ok
+	case TypeString:
+		_, ok := v.(string)
+		return ok
+	case TypeNumber:
+		_, ok := toFloat(v)
+		return ok
+	case TypeInteger:
+		n, ok := toFloat(v)
+		return ok && n == math.Trunc(n)
+	case TypeArray:
+		_, ok := v.([]any)
+		return ok
+	case TypeObject:
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+func typeOf(v any) string {
+	switch {
+	case v == nil:
+		return TypeNull
+	case isType(v, TypeBoolean):
+		return TypeBoolean
+	case isType(v, TypeString):
+		return TypeString
+	case isType(v, TypeInteger):
+		return TypeInteger
+	case isType(v, TypeNumber):
+		return TypeNumber
+	case isType(v, TypeArray):
+		return TypeArray
+	case isType(v, TypeObject):
+		return TypeObject
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func equal(a, b any) bool {
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func format(v any) string {
+	switch val := v.(type) {
+	case string:
+		return "`" + val + "`"
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
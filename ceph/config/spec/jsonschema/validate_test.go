@@ -0,0 +1,105 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	s, err := Reflect(testStruct{})
+	require.NoError(t, err)
+
+	type testCase struct {
+		name   string
+		in     any
+		expOut []ValidationError
+	}
+
+	tcs := []testCase{
+		{
+			name: "valid",
+			in: map[string]any{
+				"name":   "test",
+				"ratio":  0.3,
+				"count":  3,
+				"mode":   "off",
+				"tags":   []any{"a", "b"},
+				"labels": map[string]any{"k": "v"},
+			},
+			expOut: []ValidationError{},
+		},
+		{
+			name:   "not an object",
+			in:     "test",
+			expOut: []ValidationError{{Message: "expected object but got string"}},
+		},
+		{
+			name: "unknown property",
+			in:   map[string]any{"nmae": "test"},
+			expOut: []ValidationError{
+				{Path: []string{"nmae"}, Message: "unexpected property"},
+			},
+		},
+		{
+			name: "wrong types",
+			in: map[string]any{
+				"count": 1.5,
+				"name":  true,
+				"tags":  []any{"a", 1},
+			},
+			expOut: []ValidationError{
+				{Path: []string{"count"}, Message: "expected integer but got number"},
+				{Path: []string{"name"}, Message: "expected string but got boolean"},
+				{Path: []string{"tags", "1"}, Message: "expected string but got integer"},
+			},
+		},
+		{
+			name: "constraints",
+			in: map[string]any{
+				"mode":  "auto",
+				"ratio": 2,
+			},
+			expOut: []ValidationError{
+				{Path: []string{"mode"}, Message: "`auto` is not one of allowed values: `on`, `off`"},
+				{Path: []string{"ratio"}, Message: "2 is greater than maximum 1"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			r.Equal(tc.expOut, s.Validate(tc.in))
+		})
+	}
+}
+
+func TestValidateRequiredAndConst(t *testing.T) {
+	r := require.New(t)
+
+	s := &Schema{
+		Type: Types{TypeObject},
+		Properties: map[string]*Schema{
+			"kind": {Const: "Test"},
+		},
+		Required: []string{"kind", "spec"},
+	}
+
+	r.Equal([]ValidationError{
+		{Message: "missing required property `spec`"},
+		{Path: []string{"kind"}, Message: "expected `Test` but got `test`"},
+	}, s.Validate(map[string]any{"kind": "test"}))
+}
+
+func TestValidationErrorString(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("`spec.osd`: unexpected property", ValidationError{
+		Path:    []string{"spec", "osd"},
+		Message: "unexpected property",
+	}.Error())
+	r.Equal("expected object but got string", ValidationError{
+		Message: "expected object but got string",
+	}.Error())
+}
@@ -0,0 +1,177 @@
+package spec
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/ceph/config/spec/jsonschema"
+	"github.com/runityru/cephctl/models"
+)
+
+var ErrUnknownKind = errors.New("unexpected specification kind")
+
+type kind struct {
+	name   string
+	schema func() (*jsonschema.Schema, error)
+}
+
+// kinds is the registry of supported specification kinds, every new kind
+// must be registered here to pass the validation
+var kinds = []kind{
+	{
+		name: "CephConfig",
+		schema: func() (*jsonschema.Schema, error) {
+			// Configuration values are always strings in Ceph but it's
+			// natural to write numbers and booleans in YAML as is
+			return &jsonschema.Schema{
+				Type:        jsonschema.Types{jsonschema.TypeObject},
+				Description: "Ceph runtime configuration (`ceph config`) by sections",
+				AdditionalProperties: &jsonschema.Schema{
+					Type: jsonschema.Types{jsonschema.TypeObject},
+					AdditionalProperties: &jsonschema.Schema{
+						Type: jsonschema.Types{
+							jsonschema.TypeString,
+							jsonschema.TypeNumber,
+							jsonschema.TypeBoolean,
+						},
+					},
+				},
+			}, nil
+		},
+	},
+	{
+		name: "CephOSDConfig",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephOSDConfig{})
+		},
+	},
+	{
+		name: "CephOSDFlags",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephOSDFlags{})
+		},
+	},
+	{
+		name: "CephMgrModules",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephMgrModules{})
+		},
+	},
+	{
+		name: "CephBalancer",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephBalancer{})
+		},
+	},
+	{
+		name: "CephHealthMutes",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephHealthMutes{})
+		},
+	},
+	{
+		name: "CephAuth",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephAuth{})
+		},
+	},
+	{
+		name: "CephFS",
+		schema: func() (*jsonschema.Schema, error) {
+			return jsonschema.Reflect(models.CephFS{})
+		},
+	},
+}
+
+// Kinds returns the list of supported specification kinds
+func Kinds() []string {
+	names := []string{}
+	for _, k := range kinds {
+		names = append(names, k.name)
+	}
+	return names
+}
+
+// Schema returns JSON schema for the document of specified kind or for any
+// supported document if kind is empty
+func Schema(kindName string) (*jsonschema.Schema, error) {
+	if kindName != "" {
+		k, ok := lookupKind(kindName)
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownKind, "`%s` (available: %s)", kindName, strings.Join(Kinds(), ", "))
+		}
+
+		s, err := documentSchema(k)
+		if err != nil {
+			return nil, err
+		}
+		s.Schema = jsonschema.Draft
+		return s, nil
+	}
+
+	s := &jsonschema.Schema{
+		Schema: jsonschema.Draft,
+		Title:  "cephctl specification",
+	}
+	for _, k := range kinds {
+		ds, err := documentSchema(k)
+		if err != nil {
+			return nil, err
+		}
+		s.OneOf = append(s.OneOf, ds)
+	}
+	s.OneOf = append(s.OneOf, includeSchema())
+
+	return s, nil
+}
+
+func lookupKind(name string) (kind, bool) {
+	for _, k := range kinds {
+		if strings.EqualFold(k.name, name) {
+			return k, true
+		}
+	}
+	return kind{}, false
+}
+
+func documentSchema(k kind) (*jsonschema.Schema, error) {
+	spec, err := k.schema()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating schema for %s", k.name)
+	}
+
+	return &jsonschema.Schema{
+		Title: k.name,
+		Type:  jsonschema.Types{jsonschema.TypeObject},
+		Properties: map[string]*jsonschema.Schema{
+			"kind": {Const: k.name},
+			"spec": spec,
+			"patch": {
+				Description: "Apply the document as a patch to the base specification: merge overrides the keys, delete removes them",
+				Type:        jsonschema.Types{jsonschema.TypeString},
+				Enum:        []any{string(PatchMerge), string(PatchDelete)},
+			},
+		},
+		Required:             []string{"kind", "spec"},
+		AdditionalProperties: jsonschema.False(),
+	}, nil
+}
+
+// includeSchema returns schema for the document including other files
+// (paths, directories or glob patterns) relative to the including one
+func includeSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Title: "Include",
+		Type:  jsonschema.Types{jsonschema.TypeObject},
+		Properties: map[string]*jsonschema.Schema{
+			"include": {
+				Description: "Path, directory or glob pattern relative to the current file or the list of them",
+				Type:        jsonschema.Types{jsonschema.TypeString, jsonschema.TypeArray},
+				Items:       &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}},
+			},
+		},
+		Required:             []string{"include"},
+		AdditionalProperties: jsonschema.False(),
+	}
+}
@@ -0,0 +1,182 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// merge combines documents of the same kind into single description
+// keeping the order kinds first appeared in. Base documents are merged
+// first and then patches are applied in order of appearance.
+func merge(docs []document) ([]Description, error) {
+	kindNames := []string{}
+	byKind := map[string][]document{}
+	for _, doc := range docs {
+		if _, ok := byKind[doc.kind.name]; !ok {
+			kindNames = append(kindNames, doc.kind.name)
+		}
+		byKind[doc.kind.name] = append(byKind[doc.kind.name], doc)
+	}
+
+	descs := []Description{}
+	conflicts := []string{}
+	for _, name := range kindNames {
+		var (
+			value  any
+			origin Origin
+		)
+
+		patches := []document{}
+		for _, doc := range byKind[name] {
+			if doc.patch != PatchNone {
+				patches = append(patches, doc)
+				continue
+			}
+
+			if value == nil {
+				value = doc.spec
+			} else {
+				for _, path := range mergeValue(value, doc.spec, nil) {
+					conflicts = append(conflicts, fmt.Sprintf(
+						"%s: `%s` is already set at %s",
+						doc.origin.Locate(path...), strings.Join(path, "."), origin.Locate(path...),
+					))
+				}
+			}
+			origin.sources = append(origin.sources, doc.origin.sources...)
+		}
+
+		for _, doc := range patches {
+			switch doc.patch {
+			case PatchMerge:
+				value = patchMerge(value, doc.spec)
+
+			case PatchDelete:
+				missing := patchDelete(value, doc.spec, nil)
+				if len(missing) > 0 {
+					msgs := []string{}
+					for _, path := range missing {
+						msgs = append(msgs, fmt.Sprintf("%s: `%s` is not set", doc.origin.Locate(path...), strings.Join(path, ".")))
+					}
+					return nil, errors.Wrap(ErrInvalidPatch, strings.Join(msgs, "; "))
+				}
+
+			default:
+				return nil, errors.Wrapf(ErrInvalidPatch, "%s: unexpected patch type `%s`", doc.origin.locate("patch"), doc.patch)
+			}
+			origin.sources = append(origin.sources, doc.origin.sources...)
+		}
+
+		if value == nil {
+			value = map[string]any{}
+		}
+
+		spec, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling intermediate data structure")
+		}
+
+		descs = append(descs, Description{
+			Kind:   name,
+			Spec:   json.RawMessage(spec),
+			Origin: origin,
+		})
+	}
+
+	if len(conflicts) > 0 {
+		return nil, errors.Wrap(ErrConflict, strings.Join(conflicts, "; "))
+	}
+
+	return descs, nil
+}
+
+// mergeValue merges src mapping into dst one recursively and returns
+// paths of the keys set in both of them
+func mergeValue(dst, src any, path []string) [][]string {
+	dm, dok := dst.(map[string]any)
+	sm, sok := src.(map[string]any)
+	if !dok || !sok {
+		return [][]string{path}
+	}
+
+	conflicts := [][]string{}
+	for _, k := range sortedKeys(sm) {
+		if _, ok := dm[k]; !ok {
+			dm[k] = sm[k]
+			continue
+		}
+
+		conflicts = append(conflicts, mergeValue(dm[k], sm[k], append(slices.Clone(path), k))...)
+	}
+
+	return conflicts
+}
+
+// patchMerge merges src mapping into dst one recursively overriding
+// the values set in both of them
+func patchMerge(dst, src any) any {
+	dm, dok := dst.(map[string]any)
+	sm, sok := src.(map[string]any)
+	if !dok || !sok {
+		return src
+	}
+
+	for _, k := range sortedKeys(sm) {
+		dm[k] = patchMerge(dm[k], sm[k])
+	}
+
+	return dm
+}
+
+// patchDelete removes the leaf keys of src mapping (including empty
+// mappings) from dst one and returns paths of the keys missing in dst.
+// Mappings left empty after removal are removed as well.
+func patchDelete(dst, src any, path []string) [][]string {
+	sm, _ := src.(map[string]any)
+	dm, ok := dst.(map[string]any)
+	if !ok {
+		dm = map[string]any{}
+	}
+
+	missing := [][]string{}
+	for _, k := range sortedKeys(sm) {
+		p := append(slices.Clone(path), k)
+
+		dv, ok := dm[k]
+		if !ok {
+			missing = append(missing, p)
+			continue
+		}
+
+		sv, ok := nonEmptyMapping(sm[k])
+		if !ok {
+			delete(dm, k)
+			continue
+		}
+
+		missing = append(missing, patchDelete(dv, sv, p)...)
+		if m, ok := dm[k].(map[string]any); ok && len(m) == 0 {
+			delete(dm, k)
+		}
+	}
+
+	return missing
+}
+
+func nonEmptyMapping(v any) (map[string]any, bool) {
+	m, ok := v.(map[string]any)
+	return m, ok && len(m) > 0
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
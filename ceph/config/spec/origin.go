@@ -0,0 +1,133 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Location points to the particular place in specification file
+type Location struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.Filename
+	}
+	return fmt.Sprintf("%s:%d:%d", l.Filename, l.Line, l.Column)
+}
+
+// Origin refers to the documents specification was read from and allows
+// to locate particular keys of the specification within these documents
+type Origin struct {
+	sources []source
+}
+
+type source struct {
+	filename string
+	document *yaml.Node
+}
+
+func newOrigin(filename string, document *yaml.Node) Origin {
+	return Origin{
+		sources: []source{{
+			filename: filename,
+			document: document,
+		}},
+	}
+}
+
+// Location returns location of the first document
+func (o Origin) Location() Location {
+	if len(o.sources) == 0 {
+		return Location{}
+	}
+	loc, _ := o.sources[0].locate(nil)
+	return loc
+}
+
+// Locate returns location of the key by its path within document spec
+// or the closest known parent location if key could not be found
+func (o Origin) Locate(path ...string) Location {
+	return o.locate(append([]string{"spec"}, path...)...)
+}
+
+// locate does the same as Locate but the path starts from document root,
+// the document with the deepest match is used (the latest one if there are
+// several of them since it's the one overriding the others)
+func (o Origin) locate(path ...string) Location {
+	loc := o.Location()
+
+	best := -1
+	for _, src := range o.sources {
+		l, depth := src.locate(path)
+		if depth >= best {
+			loc, best = l, depth
+		}
+	}
+
+	return loc
+}
+
+// locate returns location of the path within the document and the amount
+// of path elements matched
+func (s source) locate(path []string) (Location, int) {
+	node := s.document
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	loc := Location{Filename: s.filename}
+	if node == nil {
+		return loc, 0
+	}
+	loc.Line, loc.Column = node.Line, node.Column
+
+	depth := 0
+	for _, p := range path {
+		key, value := lookupChild(node, p)
+		if key == nil {
+			break
+		}
+
+		loc.Line = key.Line
+		loc.Column = key.Column
+		node = value
+		depth++
+	}
+
+	return loc, depth
+}
+
+// lookupChild returns the key (or item itself for sequences) and value nodes
+// of the mapping or sequence node
+func lookupChild(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(key)
+		if err == nil && idx >= 0 && idx < len(node.Content) {
+			return node.Content[idx], node.Content[idx]
+		}
+	}
+
+	return nil, nil
+}
@@ -1,53 +1,316 @@
 package spec
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/ceph/config/spec/jsonschema"
+	"github.com/runityru/cephctl/models"
+)
+
+var (
+	ErrInvalidDocument = errors.New("specification document does not match the schema")
+	ErrConflict        = errors.New("conflicting specification documents")
+	ErrIncludeCycle    = errors.New("include cycle detected")
+	ErrNoFiles         = errors.New("no specification files found")
+	ErrInvalidPatch    = errors.New("invalid patch document")
 )
 
 type Description struct {
-	Kind string          `json:"kind"`
-	Spec json.RawMessage `json:"spec"`
+	Kind   string          `json:"kind"`
+	Spec   json.RawMessage `json:"spec"`
+	Origin Origin          `json:"-"`
 }
 
 type yamlIntermediate struct {
-	Kind string `yaml:"kind"`
-	Spec any    `yaml:"spec"`
+	Kind    string `yaml:"kind"`
+	Spec    any    `yaml:"spec"`
+	Patch   Patch  `yaml:"patch"`
+	Include any    `yaml:"include"`
+}
+
+// Patch is the way the document modifies the base specification
+type Patch string
+
+const (
+	// PatchNone means the document is the part of base specification
+	PatchNone Patch = ""
+	// PatchMerge overrides the keys of base specification
+	PatchMerge Patch = "merge"
+	// PatchDelete removes the keys of base specification, values are ignored
+	PatchDelete Patch = "delete"
+)
+
+// document is a single specification document as it's read from the file
+type document struct {
+	kind   kind
+	spec   any
+	patch  Patch
+	origin Origin
+}
+
+// NewFromDescription reads specification documents from the file, directory
+// (all of the *.yaml and *.yml files within it) or files matching glob
+// pattern in lexical order following `include` directives. Documents of the
+// same kind are merged into single description in order of appearance,
+// setting the same key in more than one document is treated as conflict.
+// Patch documents are applied after all of the base documents are merged.
+//
+// Files marked with `# cephctl: template` comment on the first line are
+// rendered as Go template (with `.Values`, `.Env` and `.Facts` available)
+// and then `${NAME}` variables are substituted from values or environment
+// before parsing.
+func NewFromDescription(path string, opts ...Option) ([]Description, error) {
+	l := &loader{
+		visiting: map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.env == nil {
+		l.env = environ()
+	}
+
+	l.data = &templateData{
+		Values:  l.values,
+		Env:     l.env,
+		factsFn: l.factsFn,
+	}
+
+	if err := l.load(path, ""); err != nil {
+		return nil, err
+	}
+
+	return merge(l.docs)
 }
 
-func NewFromDescription(filename string) (docs []Description, err error) {
-	fp, err := os.Open(filename)
+type loader struct {
+	docs     []document
+	visiting map[string]bool
+
+	values  map[string]any
+	env     map[string]string
+	factsFn func() (models.ClusterFacts, error)
+	data    *templateData
+}
+
+// load resolves path relative to baseDir and loads all of the files it
+// points to
+func (l *loader) load(path, baseDir string) error {
+	if baseDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	files, err := resolve(path)
 	if err != nil {
-		return nil, errors.Wrap(err, "error opening spec file")
+		return err
 	}
-	defer func() {
-		err = fp.Close()
-	}()
 
-	dec := yaml.NewDecoder(fp)
+	for _, filename := range files {
+		if err := l.loadFile(filename); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *loader) loadFile(filename string) error {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return errors.Wrap(err, "error resolving absolute path")
+	}
+
+	if l.visiting[absPath] {
+		return errors.Wrapf(ErrIncludeCycle, "`%s`", filename)
+	}
+	l.visiting[absPath] = true
+	defer delete(l.visiting, absPath)
+
+	in, err := os.ReadFile(filename)
+	if err != nil {
+		return errors.Wrap(err, "error reading spec file")
+	}
+
+	if isTemplate(in) {
+		in, err = render(filename, in, l.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(in))
 	for {
-		v := yamlIntermediate{}
-		err := dec.Decode(&v)
+		node := &yaml.Node{}
+		err := dec.Decode(node)
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return nil, errors.Wrap(err, "error unmarshaling document")
+			return errors.Wrapf(err, "%s: error unmarshaling document", filename)
+		}
+
+		if isEmpty(node) {
+			continue
+		}
+
+		v := yamlIntermediate{}
+		if err := node.Decode(&v); err != nil {
+			return errors.Wrapf(err, "%s: error unmarshaling document", filename)
+		}
+
+		origin := newOrigin(filename, node)
+
+		if key, _ := lookupChild(node, "include"); key != nil {
+			if err := l.include(origin, node, v.Include); err != nil {
+				return err
+			}
+			continue
 		}
-		spec, err := json.Marshal(v.Spec)
+
+		k, ok := lookupKind(v.Kind)
+		if !ok {
+			return errors.Wrapf(ErrUnknownKind, "%s: `%s` (available: %s)", origin.locate("kind"), v.Kind, strings.Join(Kinds(), ", "))
+		}
+
+		schema, err := documentSchema(k)
 		if err != nil {
-			return nil, errors.Wrap(err, "error marshaling intermediate data structure")
+			return err
+		}
+		// Kind is matched case-insensitively and is already checked above
+		schema.Properties["kind"] = &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+		if v.Patch == PatchDelete {
+			// Only the paths matter for deletion
+			schema.Properties["spec"] = &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeObject}}
 		}
 
-		docs = append(docs, Description{
-			Kind: v.Kind,
-			Spec: json.RawMessage(spec),
+		if err := validate(origin, node, schema); err != nil {
+			return err
+		}
+
+		l.docs = append(l.docs, document{
+			kind:   k,
+			spec:   v.Spec,
+			patch:  v.Patch,
+			origin: origin,
 		})
 	}
 
-	return docs, err
+	return nil
+}
+
+func (l *loader) include(origin Origin, node *yaml.Node, include any) error {
+	if err := validate(origin, node, includeSchema()); err != nil {
+		return err
+	}
+
+	patterns := []string{}
+	switch v := include.(type) {
+	case string:
+		patterns = append(patterns, v)
+	case []any:
+		for _, p := range v {
+			patterns = append(patterns, p.(string))
+		}
+	}
+
+	for _, p := range patterns {
+		if err := l.load(p, filepath.Dir(origin.Location().Filename)); err != nil {
+			return errors.Wrapf(err, "%s: error including `%s`", origin.locate("include"), p)
+		}
+	}
+	return nil
+}
+
+// resolve returns the list of files the path points to in lexical order
+func resolve(path string) ([]string, error) {
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error matching pattern `%s`", path)
+		}
+
+		if len(matches) == 0 {
+			return nil, errors.Wrapf(ErrNoFiles, "no files match pattern `%s`", path)
+		}
+		slices.Sort(matches)
+
+		files := []string{}
+		for _, m := range matches {
+			fs, err := resolve(m)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, fs...)
+		}
+		return files, nil
+	}
+
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening spec file")
+	}
+
+	if !st.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading spec directory")
+	}
+
+	files := []string{}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		files = append(files, filepath.Join(path, e.Name()))
+	}
+
+	if len(files) == 0 {
+		return nil, errors.Wrapf(ErrNoFiles, "no *.yaml or *.yml files in `%s`", path)
+	}
+	return files, nil
+}
+
+// validate checks the document against JSON schema
+func validate(origin Origin, node *yaml.Node, schema *jsonschema.Schema) error {
+	var doc any
+	if err := node.Decode(&doc); err != nil {
+		return errors.Wrap(err, "error unmarshaling document")
+	}
+
+	verrs := schema.Validate(doc)
+	if len(verrs) == 0 {
+		return nil
+	}
+
+	msgs := []string{}
+	for _, verr := range verrs {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", origin.locate(verr.Path...), verr))
+	}
+
+	return errors.Wrap(ErrInvalidDocument, strings.Join(msgs, "; "))
+}
+
+func isEmpty(node *yaml.Node) bool {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return true
+		}
+		node = node.Content[0]
+	}
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
 }
@@ -29,3 +29,154 @@ func TestNewFromDescriptionMulti(t *testing.T) {
 	r.Equal("CephOSDConfig", descs[1].Kind)
 	r.JSONEq(`{"allow_crimson":true}`, string(descs[1].Spec))
 }
+
+func TestOriginLocate(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/sample_NewFromDescriptionMulti.yaml")
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:2:1", descs[0].Origin.Location().String())
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:5:5", descs[0].Origin.Locate("global", "rbd_cache").String())
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:6:3", descs[0].Origin.Locate("osd").String())
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:6:3", descs[0].Origin.Locate("osd", "unknown").String())
+
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:9:1", descs[1].Origin.Location().String())
+	r.Equal("testdata/sample_NewFromDescriptionMulti.yaml:11:3", descs[1].Origin.Locate("allow_crimson").String())
+}
+
+func TestNewFromDescriptionInvalid(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/sample_NewFromDescriptionInvalid.yaml")
+	r.ErrorIs(err, ErrInvalidDocument)
+	r.Equal(
+		"testdata/sample_NewFromDescriptionInvalid.yaml:13:1: `extra`: unexpected property; "+
+			"testdata/sample_NewFromDescriptionInvalid.yaml:10:3: `spec.allow_crimson`: expected boolean but got string; "+
+			"testdata/sample_NewFromDescriptionInvalid.yaml:11:3: `spec.full_ratio`: 1.5 is greater than maximum 1; "+
+			"testdata/sample_NewFromDescriptionInvalid.yaml:12:3: `spec.nearful_ratio`: unexpected property: "+
+			"specification document does not match the schema",
+		err.Error(),
+	)
+}
+
+func TestNewFromDescriptionUnknownKind(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/sample_NewFromDescriptionUnknownKind.yaml")
+	r.ErrorIs(err, ErrUnknownKind)
+	r.Equal(
+		"testdata/sample_NewFromDescriptionUnknownKind.yaml:2:1: `CephUnknown` (available: CephConfig, CephOSDConfig, CephOSDFlags, CephMgrModules, CephBalancer, CephHealthMutes, CephAuth, CephFS): unexpected specification kind",
+		err.Error(),
+	)
+}
+
+func TestSchema(t *testing.T) {
+	r := require.New(t)
+
+	s, err := Schema("")
+	r.NoError(err)
+	r.Len(s.OneOf, len(Kinds())+1)
+
+	_, err = Schema("cephconfig")
+	r.NoError(err)
+
+	_, err = Schema("unknown")
+	r.ErrorIs(err, ErrUnknownKind)
+}
+
+func TestNewFromDescriptionDirectory(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/dir")
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.Equal("CephConfig", descs[0].Kind)
+	r.JSONEq(`{"global":{"rbd_cache":"true"},"osd":{"osd_max_backfills":"1","osd_recovery_max_active":"3"}}`, string(descs[0].Spec))
+	r.Equal("testdata/dir/00-base.yaml:7:5", descs[0].Origin.Locate("osd", "osd_max_backfills").String())
+	r.Equal("testdata/dir/10-osd.yml:5:5", descs[0].Origin.Locate("osd", "osd_recovery_max_active").String())
+
+	r.Equal("CephOSDConfig", descs[1].Kind)
+	r.JSONEq(`{"allow_crimson":true}`, string(descs[1].Spec))
+}
+
+func TestNewFromDescriptionGlob(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/dir/*.yml")
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.Equal("CephConfig", descs[0].Kind)
+	r.JSONEq(`{"osd":{"osd_recovery_max_active":"3"}}`, string(descs[0].Spec))
+}
+
+func TestNewFromDescriptionGlobNoMatches(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/dir/*.json")
+	r.ErrorIs(err, ErrNoFiles)
+}
+
+func TestNewFromDescriptionInclude(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/include.yaml")
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.Equal("CephConfig", descs[0].Kind)
+	r.JSONEq(`{"global":{"rbd_cache":"true"},"osd":{"osd_max_backfills":"1","osd_recovery_max_active":"3"}}`, string(descs[0].Spec))
+
+	r.Equal("CephOSDConfig", descs[1].Kind)
+	r.JSONEq(`{"allow_crimson":true,"full_ratio":0.9}`, string(descs[1].Spec))
+	r.Equal("testdata/include.yaml:7:3", descs[1].Origin.Locate("full_ratio").String())
+	r.Equal("testdata/dir/10-osd.yml:9:3", descs[1].Origin.Locate("allow_crimson").String())
+}
+
+func TestNewFromDescriptionConflict(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/conflict")
+	r.ErrorIs(err, ErrConflict)
+	r.Equal(
+		"testdata/conflict/b.yaml:7:5: `osd.osd_max_backfills` is already set at testdata/conflict/a.yaml:5:5: conflicting specification documents",
+		err.Error(),
+	)
+}
+
+func TestNewFromDescriptionIncludeCycle(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/cycle/a.yaml")
+	r.ErrorIs(err, ErrIncludeCycle)
+}
+
+func TestNewFromDescriptionOverlay(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/overlay/clusters/c01.yaml")
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.Equal("CephConfig", descs[0].Kind)
+	r.JSONEq(`{"global":{"rbd_cache":"true"},"mon":{"mon_allow_pool_delete":"false"},"osd":{"osd_max_backfills":"4"}}`, string(descs[0].Spec))
+	r.Equal("testdata/overlay/clusters/c01.yaml:8:5", descs[0].Origin.Locate("osd", "osd_max_backfills").String())
+	r.Equal("testdata/overlay/base.yaml:5:5", descs[0].Origin.Locate("global", "rbd_cache").String())
+
+	r.Equal("CephOSDConfig", descs[1].Kind)
+	r.JSONEq(`{"full_ratio":0.95,"nearfull_ratio":0.8}`, string(descs[1].Spec))
+}
+
+func TestNewFromDescriptionOverlayDeleteMissing(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/overlay/clusters/c02.yaml")
+	r.ErrorIs(err, ErrInvalidPatch)
+	r.Equal(
+		"testdata/overlay/clusters/c02.yaml:8:5: `osd.osd_max_backfils` is not set: invalid patch document",
+		err.Error(),
+	)
+}
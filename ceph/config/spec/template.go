@@ -0,0 +1,253 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+)
+
+var (
+	ErrTemplate = errors.New("error rendering specification template")
+
+	ErrFactsNotAvailable = errors.New("cluster facts are not available")
+)
+
+// templateMarker is the comment on the first line of the file enabling
+// templating, files without it are parsed as is
+const templateMarker = "# cephctl: template"
+
+var (
+	variableRe     = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+	variableNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*$`)
+	templateErrRe  = regexp.MustCompile(`^template: spec:(\d+)(?::(\d+))?: (?:executing "spec" at <[^>]*>: )?(.*)$`)
+	separatorRe    = regexp.MustCompile(`^---(\s|$)`)
+)
+
+// Option configures specification loading
+type Option func(*loader)
+
+// WithValues sets values available in templates as `.Values` and as `${NAME}`
+// variables where nested values are accessible by dot-separated path
+func WithValues(values map[string]any) Option {
+	return func(l *loader) {
+		l.values = values
+	}
+}
+
+// WithEnv overrides environment variables available in templates as `.Env`
+// and as `${NAME}` variables (process environment is used by default)
+func WithEnv(env map[string]string) Option {
+	return func(l *loader) {
+		l.env = env
+	}
+}
+
+// WithFacts sets the function providing cluster facts available in
+// templates as `.Facts`. The function is called only if facts are used.
+func WithFacts(fn func() (models.ClusterFacts, error)) Option {
+	return func(l *loader) {
+		l.factsFn = fn
+	}
+}
+
+// LoadValues reads values for templates from YAML file
+func LoadValues(filename string) (map[string]any, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading values file")
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrap(err, "error decoding values file")
+	}
+
+	return values, nil
+}
+
+// templateData is the data passed to specification templates
+type templateData struct {
+	Values map[string]any
+	Env    map[string]string
+
+	factsFn   func() (models.ClusterFacts, error)
+	factsOnce sync.Once
+	facts     models.ClusterFacts
+	factsErr  error
+}
+
+// Facts returns cluster facts retrieving them on the first call
+func (d *templateData) Facts() (models.ClusterFacts, error) {
+	d.factsOnce.Do(func() {
+		if d.factsFn == nil {
+			d.factsErr = ErrFactsNotAvailable
+			return
+		}
+		d.facts, d.factsErr = d.factsFn()
+	})
+	return d.facts, d.factsErr
+}
+
+var templateFuncs = template.FuncMap{
+	"add": func(a, b uint64) uint64 { return a + b },
+	"sub": func(a, b uint64) uint64 { return a - b },
+	"mul": func(a, b uint64) uint64 { return a * b },
+	"div": func(a, b uint64) (uint64, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	},
+}
+
+// isTemplate reports whether the file is marked as template
+func isTemplate(in []byte) bool {
+	line, _, _ := bytes.Cut(in, []byte("\n"))
+	return strings.TrimSpace(string(line)) == templateMarker
+}
+
+// render executes Go template and then substitutes `${NAME}` variables
+// (`$${NAME}` is kept as `${NAME}`) within the file contents
+func render(filename string, in []byte, data *templateData) ([]byte, error) {
+	tpl, err := template.New("spec").
+		Option("missingkey=error").
+		Funcs(templateFuncs).
+		Parse(string(in))
+	if err != nil {
+		return nil, templateError(filename, in, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.Execute(buf, data); err != nil {
+		return nil, templateError(filename, in, err)
+	}
+
+	return substitute(filename, buf.Bytes(), data)
+}
+
+func substitute(filename string, in []byte, data *templateData) ([]byte, error) {
+	out := &bytes.Buffer{}
+	msgs := []string{}
+	last := 0
+	for _, m := range variableRe.FindAllSubmatchIndex(in, -1) {
+		out.Write(in[last:m[0]])
+		last = m[1]
+
+		match := in[m[0]:m[1]]
+		if bytes.HasPrefix(match, []byte("$$")) {
+			out.Write(match[1:])
+			continue
+		}
+
+		value, err := data.lookup(string(in[m[2]:m[3]]))
+		if err != nil {
+			line := bytes.Count(in[:m[0]], []byte("\n")) + 1
+			msgs = append(msgs, fmt.Sprintf("%s:%d: document %d: `%s`: %s", filename, line, documentAt(in, line), match, err))
+			continue
+		}
+		out.WriteString(value)
+	}
+	out.Write(in[last:])
+
+	if len(msgs) > 0 {
+		return nil, errors.Wrap(ErrTemplate, strings.Join(msgs, "; "))
+	}
+
+	return out.Bytes(), nil
+}
+
+func (d *templateData) lookup(name string) (string, error) {
+	if !variableNameRe.MatchString(name) {
+		return "", errors.New("invalid variable name")
+	}
+
+	var v any = d.Values
+	found := true
+	for _, p := range strings.Split(name, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			found = false
+			break
+		}
+
+		v, ok = m[p]
+		if !ok {
+			found = false
+			break
+		}
+	}
+
+	if found {
+		switch v.(type) {
+		case map[string]any, []any:
+			return "", errors.New("value is not a scalar")
+		}
+		return fmt.Sprint(v), nil
+	}
+
+	if v, ok := d.Env[name]; ok {
+		return v, nil
+	}
+
+	return "", errors.New("variable is not defined")
+}
+
+// templateError converts Go template error to the one pointing to the file,
+// line and document within the file
+func templateError(filename string, in []byte, err error) error {
+	m := templateErrRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return errors.Wrapf(ErrTemplate, "%s: %s", filename, err)
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	loc := filename + ":" + m[1]
+	if m[2] != "" {
+		loc += ":" + m[2]
+	}
+
+	return errors.Wrapf(ErrTemplate, "%s: document %d: %s", loc, documentAt(in, line), m[3])
+}
+
+// documentAt returns 1-based number of YAML document the line belongs to
+func documentAt(in []byte, line int) int {
+	doc := 1
+	seenContent := false
+	for i, l := range strings.Split(string(in), "\n") {
+		if i+1 >= line {
+			break
+		}
+
+		if separatorRe.MatchString(l) {
+			if seenContent {
+				doc++
+			}
+			seenContent = false
+			continue
+		}
+
+		if t := strings.TrimSpace(l); t != "" && !strings.HasPrefix(t, "#") {
+			seenContent = true
+		}
+	}
+	return doc
+}
+
+func environ() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		env[k] = v
+	}
+	return env
+}
@@ -0,0 +1,110 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestNewFromDescriptionTemplate(t *testing.T) {
+	r := require.New(t)
+
+	values, err := LoadValues("testdata/template/values.yaml")
+	r.NoError(err)
+
+	descs, err := NewFromDescription("testdata/template/spec.yaml",
+		WithValues(values),
+		WithEnv(map[string]string{"RGW_DNS_NAME": "s3.example.com"}),
+		WithFacts(func() (models.ClusterFacts, error) {
+			return models.ClusterFacts{
+				Hosts: map[string]models.HostFacts{
+					"host1": {MemoryPerOSDBytes: 10 << 30},
+					"host2": {MemoryPerOSDBytes: 5 << 30},
+				},
+			}, nil
+		}),
+	)
+	r.NoError(err)
+	r.Len(descs, 2)
+
+	r.JSONEq(`{"full_ratio":0.9}`, string(descs[0].Spec))
+	r.JSONEq(`{
+		"global": {
+			"mon_host": "[v2:10.0.0.1:3300]",
+			"rgw_dns_name": "s3.example.com",
+			"literal": "${NOT_A_VARIABLE}"
+		},
+		"osd/host:host1": {"osd_memory_target": "8589934592"},
+		"osd/host:host2": {"osd_memory_target": "4294967296"}
+	}`, string(descs[1].Spec))
+}
+
+func TestNewFromDescriptionWithoutTemplateMarker(t *testing.T) {
+	r := require.New(t)
+
+	descs, err := NewFromDescription("testdata/template/literal.yaml",
+		WithValues(map[string]any{}),
+		WithEnv(map[string]string{}),
+	)
+	r.NoError(err)
+	r.Len(descs, 1)
+	r.JSONEq(`{
+		"client.radosgw": {
+			"rgw_frontends": "beast port=7480 ssl_private_key=${KEY}",
+			"rgw_dns_name": "{{ .Values.rgw_dns_name }}"
+		}
+	}`, string(descs[0].Spec))
+}
+
+func TestNewFromDescriptionTemplateFactsAreLazy(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewFromDescription("testdata/sample_NewFromDescriptionSingle.yaml",
+		WithFacts(func() (models.ClusterFacts, error) {
+			r.FailNow("facts must not be retrieved")
+			return models.ClusterFacts{}, nil
+		}),
+	)
+	r.NoError(err)
+}
+
+func TestNewFromDescriptionTemplateErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		filename string
+		expError string
+	}
+
+	tcs := []testCase{
+		{
+			name:     "undefined variable",
+			filename: "testdata/template/undefined.yaml",
+			expError: "testdata/template/undefined.yaml:10: document 2: `${UNDEFINED_VARIABLE}`: variable is not defined: error rendering specification template",
+		},
+		{
+			name:     "missing key",
+			filename: "testdata/template/missingkey.yaml",
+			expError: "testdata/template/missingkey.yaml:11:33: document 2: map has no entry for key \"memory_target\": error rendering specification template",
+		},
+		{
+			name:     "facts not available",
+			filename: "testdata/template/nofacts.yaml",
+			expError: "testdata/template/nofacts.yaml:6:23: document 1: error calling Facts: cluster facts are not available: error rendering specification template",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			_, err := NewFromDescription(tc.filename,
+				WithValues(map[string]any{}),
+				WithEnv(map[string]string{}),
+			)
+			r.ErrorIs(err, ErrTemplate)
+			r.Equal(tc.expError, err.Error())
+		})
+	}
+}
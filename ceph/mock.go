@@ -2,6 +2,7 @@ package ceph
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -18,8 +19,8 @@ func NewMock() *Mock {
 	return &Mock{}
 }
 
-func (m *Mock) ApplyCephConfigOption(ctx context.Context, section, key, value string) error {
-	args := m.Called(section, key, value)
+func (m *Mock) ApplyCephConfigOption(ctx context.Context, target, key, value string) error {
+	args := m.Called(target, key, value)
 	return args.Error(0)
 }
 
@@ -28,6 +29,11 @@ func (m *Mock) ApplyCephOSDConfigOption(_ context.Context, key, value string) er
 	return args.Error(0)
 }
 
+func (m *Mock) BalancerStatus(_ context.Context) (models.BalancerStatus, error) {
+	args := m.Called()
+	return args.Get(0).(models.BalancerStatus), args.Error(1)
+}
+
 func (m *Mock) ClusterReport(ctx context.Context) (models.ClusterReport, error) {
 	args := m.Called()
 	return args.Get(0).(models.ClusterReport), args.Error(1)
@@ -38,17 +44,117 @@ func (m *Mock) ClusterStatus(ctx context.Context) (models.ClusterStatus, error)
 	return args.Get(0).(models.ClusterStatus), args.Error(1)
 }
 
+func (m *Mock) DaemonVersions(_ context.Context) (map[string]map[string]uint16, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]map[string]uint16), args.Error(1)
+}
+
+func (m *Mock) DisableMgrModule(_ context.Context, name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
 func (m *Mock) DumpConfig(_ context.Context) (models.CephConfig, error) {
 	args := m.Called()
 	return args.Get(0).(models.CephConfig), args.Error(1)
 }
 
+func (m *Mock) EnableMgrModule(_ context.Context, name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *Mock) GetMaintenanceLease(_ context.Context) (*models.MaintenanceLease, error) {
+	args := m.Called()
+	return args.Get(0).(*models.MaintenanceLease), args.Error(1)
+}
+
+func (m *Mock) ListAuthEntities(_ context.Context) ([]models.AuthEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AuthEntity), args.Error(1)
+}
+
 func (m *Mock) ListDevices(_ context.Context) ([]models.Device, error) {
 	args := m.Called()
 	return args.Get(0).([]models.Device), args.Error(1)
 }
 
-func (m *Mock) RemoveCephConfigOption(ctx context.Context, section, key string) error {
-	args := m.Called(section, key)
+func (m *Mock) ListFileSystems(_ context.Context) ([]models.FileSystem, error) {
+	args := m.Called()
+	return args.Get(0).([]models.FileSystem), args.Error(1)
+}
+
+func (m *Mock) ListMgrModules(_ context.Context) (models.MgrModules, error) {
+	args := m.Called()
+	return args.Get(0).(models.MgrModules), args.Error(1)
+}
+
+func (m *Mock) ListOSDUsage(_ context.Context) ([]models.OSDUsage, error) {
+	args := m.Called()
+	return args.Get(0).([]models.OSDUsage), args.Error(1)
+}
+
+func (m *Mock) MuteHealthCheck(_ context.Context, code string, ttl time.Duration, sticky bool) error {
+	args := m.Called(code, ttl, sticky)
+	return args.Error(0)
+}
+
+func (m *Mock) OSDOkToStop(_ context.Context, ids []uint16) (models.OSDOkToStop, error) {
+	args := m.Called(ids)
+	return args.Get(0).(models.OSDOkToStop), args.Error(1)
+}
+
+func (m *Mock) OSDSafeToDestroy(_ context.Context, ids []uint16) (models.OSDSafeToDestroy, error) {
+	args := m.Called(ids)
+	return args.Get(0).(models.OSDSafeToDestroy), args.Error(1)
+}
+
+func (m *Mock) RemoveCephConfigOption(ctx context.Context, target, key string) error {
+	args := m.Called(target, key)
+	return args.Error(0)
+}
+
+func (m *Mock) RemoveMaintenanceLease(_ context.Context) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *Mock) SetAuthCaps(_ context.Context, entity string, caps map[string]string) error {
+	args := m.Called(entity, caps)
+	return args.Error(0)
+}
+
+func (m *Mock) SetBalancerActive(_ context.Context, active bool) error {
+	args := m.Called(active)
+	return args.Error(0)
+}
+
+func (m *Mock) SetBalancerMode(_ context.Context, mode string) error {
+	args := m.Called(mode)
+	return args.Error(0)
+}
+
+func (m *Mock) SetFileSystemOption(_ context.Context, fs, key, value string) error {
+	args := m.Called(fs, key, value)
+	return args.Error(0)
+}
+
+func (m *Mock) SetMaintenanceLease(_ context.Context, lease models.MaintenanceLease) error {
+	args := m.Called(lease)
+	return args.Error(0)
+}
+
+func (m *Mock) SetOSDFlag(_ context.Context, flag, who string) error {
+	args := m.Called(flag, who)
+	return args.Error(0)
+}
+
+func (m *Mock) UnmuteHealthCheck(_ context.Context, code string) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *Mock) UnsetOSDFlag(_ context.Context, flag, who string) error {
+	args := m.Called(flag, who)
 	return args.Error(0)
 }
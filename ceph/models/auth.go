@@ -0,0 +1,33 @@
+package models
+
+import (
+	"maps"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// AuthList is the output of `ceph auth ls`
+type AuthList struct {
+	AuthDump []AuthListEntity `json:"auth_dump"`
+}
+
+type AuthListEntity struct {
+	Entity string            `json:"entity"`
+	Key    string            `json:"key"`
+	Caps   map[string]string `json:"caps"`
+}
+
+func (l AuthList) ToSvc() []models.AuthEntity {
+	entities := []models.AuthEntity{}
+	for _, e := range l.AuthDump {
+		caps := map[string]string{}
+		maps.Copy(caps, e.Caps)
+
+		entities = append(entities, models.AuthEntity{
+			Name: e.Entity,
+			Key:  e.Key,
+			Caps: caps,
+		})
+	}
+	return entities
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/runityru/cephctl/models"
+)
+
+// BalancerStatus is the output of `ceph balancer status`
+type BalancerStatus struct {
+	Active               bool   `json:"active"`
+	Mode                 string `json:"mode"`
+	NoOptimizationNeeded bool   `json:"no_optimization_needed"`
+	OptimizeResult       string `json:"optimize_result"`
+}
+
+func (s BalancerStatus) ToSvc() models.BalancerStatus {
+	return models.BalancerStatus{
+		Mode:                 s.Mode,
+		Active:               s.Active,
+		NoOptimizationNeeded: s.NoOptimizationNeeded,
+		OptimizeResult:       s.OptimizeResult,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// MaintenanceLease is the maintenance lease representation stored
+// in config-key store
+type MaintenanceLease struct {
+	Who       string                `json:"who"`
+	Flags     MaintenanceLeaseFlags `json:"flags"`
+	StartedAt time.Time             `json:"started_at"`
+	ExpiresAt time.Time             `json:"expires_at"`
+}
+
+type MaintenanceLeaseFlags struct {
+	Cluster    []string            `json:"cluster,omitempty"`
+	OSDs       map[string][]string `json:"osds,omitempty"`
+	CRUSHNodes map[string][]string `json:"crush_nodes,omitempty"`
+}
+
+func NewMaintenanceLease(l models.MaintenanceLease) MaintenanceLease {
+	return MaintenanceLease{
+		Who: l.Who,
+		Flags: MaintenanceLeaseFlags{
+			Cluster:    l.Flags.Cluster,
+			OSDs:       l.Flags.OSDs,
+			CRUSHNodes: l.Flags.CRUSHNodes,
+		},
+		StartedAt: l.StartedAt.UTC(),
+		ExpiresAt: l.ExpiresAt.UTC(),
+	}
+}
+
+func (l MaintenanceLease) ToSvc() models.MaintenanceLease {
+	return models.MaintenanceLease{
+		Who: l.Who,
+		Flags: models.CephOSDFlags{
+			Cluster:    l.Flags.Cluster,
+			OSDs:       l.Flags.OSDs,
+			CRUSHNodes: l.Flags.CRUSHNodes,
+		},
+		StartedAt: l.StartedAt,
+		ExpiresAt: l.ExpiresAt,
+	}
+}
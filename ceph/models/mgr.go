@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/runityru/cephctl/models"
+)
+
+// MgrModuleList is the output of `ceph mgr module ls`
+type MgrModuleList struct {
+	AlwaysOnModules []string                `json:"always_on_modules"`
+	EnabledModules  []string                `json:"enabled_modules"`
+	DisabledModules []MgrModuleListDisabled `json:"disabled_modules"`
+}
+
+type MgrModuleListDisabled struct {
+	Name        string `json:"name"`
+	CanRun      bool   `json:"can_run"`
+	ErrorString string `json:"error_string"`
+}
+
+func (l MgrModuleList) ToSvc() models.MgrModules {
+	disabled := []string{}
+	for _, m := range l.DisabledModules {
+		disabled = append(disabled, m.Name)
+	}
+
+	return models.MgrModules{
+		AlwaysOn: append([]string{}, l.AlwaysOnModules...),
+		Enabled:  append([]string{}, l.EnabledModules...),
+		Disabled: disabled,
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"github.com/runityru/cephctl/models"
+)
+
+// OSDOkToStop is the output of `ceph osd ok-to-stop`
+type OSDOkToStop struct {
+	OkToStop          bool     `json:"ok_to_stop"`
+	OSDs              []uint16 `json:"osds"`
+	NumOkPGs          uint32   `json:"num_ok_pgs"`
+	NumNotOkPGs       uint32   `json:"num_not_ok_pgs"`
+	BadBecomeInactive []string `json:"bad_become_inactive"`
+	OkBecomeDegraded  []string `json:"ok_become_degraded"`
+}
+
+func (o OSDOkToStop) ToSvc() models.OSDOkToStop {
+	return models.OSDOkToStop{
+		OkToStop:          o.OkToStop,
+		OSDs:              append([]uint16{}, o.OSDs...),
+		NumOkPGs:          o.NumOkPGs,
+		NumNotOkPGs:       o.NumNotOkPGs,
+		BadBecomeInactive: append([]string{}, o.BadBecomeInactive...),
+		OkBecomeDegraded:  append([]string{}, o.OkBecomeDegraded...),
+	}
+}
+
+// OSDSafeToDestroy is the output of `ceph osd safe-to-destroy`
+type OSDSafeToDestroy struct {
+	SafeToDestroy []uint16 `json:"safe_to_destroy"`
+	Active        []uint16 `json:"active"`
+	MissingStats  []uint16 `json:"missing_stats"`
+	StoredPGs     []uint16 `json:"stored_pgs"`
+}
+
+func (o OSDSafeToDestroy) ToSvc() models.OSDSafeToDestroy {
+	return models.OSDSafeToDestroy{
+		SafeToDestroy: append([]uint16{}, o.SafeToDestroy...),
+		Active:        append([]uint16{}, o.Active...),
+		MissingStats:  append([]uint16{}, o.MissingStats...),
+		StoredPGs:     append([]uint16{}, o.StoredPGs...),
+	}
+}
+
+type OSDDfNode struct {
+	ID          int     `json:"id"`
+	DeviceClass string  `json:"device_class"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	CrushWeight float64 `json:"crush_weight"`
+	Reweight    float64 `json:"reweight"`
+	Kb          uint64  `json:"kb"`
+	KbUsed      uint64  `json:"kb_used"`
+	KbUsedData  uint64  `json:"kb_used_data"`
+	KbUsedOmap  uint64  `json:"kb_used_omap"`
+	KbUsedMeta  uint64  `json:"kb_used_meta"`
+	KbAvail     uint64  `json:"kb_avail"`
+	Utilization float64 `json:"utilization"`
+	Var         float64 `json:"var"`
+	PGs         uint32  `json:"pgs"`
+	Status      string  `json:"status"`
+}
+
+// OSDDf is the output of `ceph osd df`
+type OSDDf struct {
+	Nodes []OSDDfNode `json:"nodes"`
+	Stray []OSDDfNode `json:"stray"`
+}
+
+func (d OSDDf) ToSvc() []models.OSDUsage {
+	nodes := append(append([]OSDDfNode{}, d.Nodes...), d.Stray...)
+
+	out := []models.OSDUsage{}
+	for _, n := range nodes {
+		if n.ID < 0 {
+			continue
+		}
+
+		out = append(out, models.OSDUsage{
+			ID:          uint16(n.ID),
+			DeviceClass: n.DeviceClass,
+			CapacityKB:  n.Kb,
+			UsedKB:      n.KbUsed,
+			AvailableKB: n.KbAvail,
+			Utilization: n.Utilization / 100,
+			Reweight:    n.Reweight,
+			NumPGs:      n.PGs,
+			Status:      n.Status,
+		})
+	}
+	return out
+}
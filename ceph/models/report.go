@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -291,8 +292,8 @@ type ReportOSDMap struct {
 	RemovedSnapsQueue   []any                                     `json:"removed_snaps_queue"`
 	NewRemovedSnaps     []any                                     `json:"new_removed_snaps"`
 	NewPurgedSnaps      []any                                     `json:"new_purged_snaps"`
-	CrushNodeFlags      struct{}                                  `json:"crush_node_flags"`
-	DeviceClassFlags    struct{}                                  `json:"device_class_flags"`
+	CrushNodeFlags      map[string][]string                       `json:"crush_node_flags"`
+	DeviceClassFlags    map[string][]string                       `json:"device_class_flags"`
 	StretchMode         ReportOSDMapStretchMode                   `json:"stretch_mode"`
 }
 
@@ -601,6 +602,21 @@ type ReportFSMap struct {
 	} `json:"filesystems"`
 }
 
+func (m ReportFSMap) ToSvc() []models.FileSystem {
+	filesystems := []models.FileSystem{}
+	for _, fs := range m.Filesystems {
+		filesystems = append(filesystems, models.FileSystem{
+			Name:               fs.Mdsmap.FsName,
+			MaxMDS:             uint16(fs.Mdsmap.MaxMds),
+			AllowStandbyReplay: fs.Mdsmap.FlagsState.AllowStandbyReplay,
+			SessionTimeout:     uint32(fs.Mdsmap.SessionTimeout),
+			MaxFileSize:        uint64(fs.Mdsmap.MaxFileSize),
+			Joinable:           fs.Mdsmap.FlagsState.Joinable,
+		})
+	}
+	return filesystems
+}
+
 type ReportAuth struct {
 	FirstCommitted int `json:"first_committed"`
 	LastCommitted  int `json:"last_committed"`
@@ -754,6 +770,19 @@ type ReportOSDSum struct {
 	NetworkPingTimes []any `json:"network_ping_times"`
 }
 
+type ReportPoolStatsStoreStats struct {
+	Total                   int   `json:"total"`
+	Available               int   `json:"available"`
+	InternallyReserved      int   `json:"internally_reserved"`
+	Allocated               int64 `json:"allocated"`
+	DataStored              int64 `json:"data_stored"`
+	DataCompressed          int   `json:"data_compressed"`
+	DataCompressedAllocated int   `json:"data_compressed_allocated"`
+	DataCompressedOriginal  int   `json:"data_compressed_original"`
+	OmapAllocated           int   `json:"omap_allocated"`
+	InternalMetadata        int   `json:"internal_metadata"`
+}
+
 type ReportPoolStats struct {
 	Poolid  int    `json:"poolid"`
 	NumPG   uint32 `json:"num_pg"`
@@ -799,23 +828,12 @@ type ReportPoolStats struct {
 		NumOmapKeys                int   `json:"num_omap_keys"`
 		NumObjectsRepaired         int   `json:"num_objects_repaired"`
 	} `json:"stat_sum"`
-	StoreStats struct {
-		Total                   int   `json:"total"`
-		Available               int   `json:"available"`
-		InternallyReserved      int   `json:"internally_reserved"`
-		Allocated               int64 `json:"allocated"`
-		DataStored              int64 `json:"data_stored"`
-		DataCompressed          int   `json:"data_compressed"`
-		DataCompressedAllocated int   `json:"data_compressed_allocated"`
-		DataCompressedOriginal  int   `json:"data_compressed_original"`
-		OmapAllocated           int   `json:"omap_allocated"`
-		InternalMetadata        int   `json:"internal_metadata"`
-	} `json:"store_stats"`
-	LogSize       int `json:"log_size"`
-	OndiskLogSize int `json:"ondisk_log_size"`
-	Up            int `json:"up"`
-	Acting        int `json:"acting"`
-	NumStoreStats int `json:"num_store_stats"`
+	StoreStats    ReportPoolStatsStoreStats `json:"store_stats"`
+	LogSize       int                       `json:"log_size"`
+	OndiskLogSize int                       `json:"ondisk_log_size"`
+	Up            int                       `json:"up"`
+	Acting        int                       `json:"acting"`
+	NumStoreStats int                       `json:"num_store_stats"`
 }
 
 type ReportPaxos struct {
@@ -1024,6 +1042,16 @@ func (r *Report) ToSvc() (models.ClusterReport, error) {
 		return models.ClusterReport{}, err
 	}
 
+	pools, err := parsePools(r.OSDMap, r.PoolStats)
+	if err != nil {
+		return models.ClusterReport{}, err
+	}
+
+	osdStates, err := parseOSDStates(r.OSDMap)
+	if err != nil {
+		return models.ClusterReport{}, err
+	}
+
 	osdDaemons := []models.OSDDaemon{}
 	for _, osd := range r.OSDMetadata {
 		frontIP, err := parseCephIPAddress(osd.FrontAddr)
@@ -1036,12 +1064,22 @@ func (r *Report) ToSvc() (models.ClusterReport, error) {
 			return models.ClusterReport{}, errors.Wrap(err, "error parsing back_addr")
 		}
 
-		memoryTotalBytes, err := strconv.ParseUint(osd.MemTotalKb, 10, 64)
+		hbFrontIP, err := parseCephIPAddress(osd.HbFrontAddr)
+		if err != nil {
+			return models.ClusterReport{}, errors.Wrap(err, "error parsing hb_front_addr")
+		}
+
+		hbBackIP, err := parseCephIPAddress(osd.HbBackAddr)
+		if err != nil {
+			return models.ClusterReport{}, errors.Wrap(err, "error parsing hb_back_addr")
+		}
+
+		memoryTotalKB, err := strconv.ParseUint(osd.MemTotalKb, 10, 64)
 		if err != nil {
 			return models.ClusterReport{}, errors.Wrap(err, "error parsing mem_total_kb value")
 		}
 
-		swapTotalBytes, err := strconv.ParseUint(osd.MemSwapKb, 10, 64)
+		swapTotalKB, err := strconv.ParseUint(osd.MemSwapKb, 10, 64)
 		if err != nil {
 			return models.ClusterReport{}, errors.Wrap(err, "error parsing mem_swap_kb value")
 		}
@@ -1051,21 +1089,43 @@ func (r *Report) ToSvc() (models.ClusterReport, error) {
 			return models.ClusterReport{}, errors.Wrap(err, "error parsing rotational value")
 		}
 
+		var capacityBytes uint64
+		if osd.BluestoreBdevSize != "" {
+			capacityBytes, err = strconv.ParseUint(osd.BluestoreBdevSize, 10, 64)
+			if err != nil {
+				return models.ClusterReport{}, errors.Wrap(err, "error parsing bluestore_bdev_size value")
+			}
+		}
+
 		osdDaemons = append(osdDaemons, models.OSDDaemon{
-			ID:               uint16(osd.ID),
-			Hostname:         osd.Hostname,
-			Architecture:     osd.Arch,
-			FrontIP:          frontIP,
-			BackIP:           backIP,
-			MemoryTotalBytes: memoryTotalBytes,
-			SwapTotalBytes:   swapTotalBytes,
-			IsRotational:     isRotational,
-			Devices:          strings.Split(osd.Devices, ","),
+			ID:                 uint16(osd.ID),
+			Hostname:           osd.Hostname,
+			Architecture:       osd.Arch,
+			KernelVersion:      osd.KernelVersion,
+			Distro:             osd.Distro,
+			DistroVersion:      osd.DistroVersion,
+			FrontIP:            frontIP,
+			BackIP:             backIP,
+			HeartbeatFrontIP:   hbFrontIP,
+			HeartbeatBackIP:    hbBackIP,
+			CapacityBytes:      capacityBytes,
+			MemoryTotalBytes:   memoryTotalKB * 1024,
+			SwapTotalBytes:     swapTotalKB * 1024,
+			IsRotational:       isRotational,
+			NUMAUnknownIfaces:  splitList(osd.NetworkNumaUnknownIfaces),
+			NUMAUnknownDevices: splitList(osd.ObjectstoreNumaUnknownDevices),
+			Devices:            strings.Split(osd.Devices, ","),
 		})
 	}
 
+	numDaemonsByVersion := map[string]map[string]uint16{
+		"osd": countOSDsByVersion(r.OSDMetadata),
+		"rgw": countRGWsByVersion(r.ServiceMap.Services.Rgw.Daemons),
+	}
+
 	return models.ClusterReport{
 		HealthStatus:                 crh,
+		CRUSHMap:                     parseCRUSHMap(r.CRUSHMap),
 		Checks:                       checks,
 		MutedChecks:                  mutes,
 		NumMons:                      uint8(numMons),
@@ -1078,8 +1138,12 @@ func (r *Report) ToSvc() (models.ClusterReport, error) {
 		NumOSDsUp:                    osdsUp,
 		NumOSDsByRelease:             countOSDsByRelease(r.OSDMetadata),
 		NumOSDsByVersion:             countOSDsByVersion(r.OSDMetadata),
+		NumDaemonsByVersion:          numDaemonsByVersion,
 		NumOSDsByDeviceType:          countOSDsByDeviceType(r.OSDMetadata),
 		OSDDaemons:                   osdDaemons,
+		OSDFlags:                     parseOSDFlags(r.OSDMap),
+		OSDStates:                    osdStates,
+		Pools:                        pools,
 		TotalOSDCapacityKB:           r.OSDSum.Kb,
 		TotalOSDUsedDataKB:           r.OSDSum.KbUsedData,
 		TotalOSDUsedMetaKB:           r.OSDSum.KbUsedMeta,
@@ -1090,10 +1154,129 @@ func (r *Report) ToSvc() (models.ClusterReport, error) {
 		BackfillfullRatio:            r.OSDMap.BackfillfullRatio,
 		FullRatio:                    r.OSDMap.FullRatio,
 		NearfullRatio:                r.OSDMap.NearfullRatio,
-		RequireMinCompatClient:       r.OSDMap.MinCompatClient,
+		RequireMinCompatClient:       r.OSDMap.RequireMinCompatClient,
+		RequireOSDRelease:            r.OSDMap.RequireOsdRelease,
+		MinCompatClient:              r.OSDMap.MinCompatClient,
 	}, nil
 }
 
+// Pool types as they're defined in pg_pool_t
+const (
+	poolTypeReplicated = 1
+	poolTypeErasure    = 3
+)
+
+func parsePools(m ReportOSDMap, stats []ReportPoolStats) ([]models.Pool, error) {
+	allocated := map[int]uint64{}
+	for _, st := range stats {
+		allocated[st.Poolid] = uint64(st.StoreStats.Allocated)
+	}
+
+	pools := []models.Pool{}
+	for _, p := range m.Pools {
+		pool := models.Pool{
+			ID:             uint32(p.Pool),
+			Name:           p.PoolName,
+			Size:           uint8(p.Size),
+			MinSize:        uint8(p.MinSize),
+			CrushRule:      p.CrushRule,
+			AllocatedBytes: allocated[p.Pool],
+		}
+
+		switch p.Type {
+		case poolTypeReplicated:
+			pool.Type = models.PoolTypeReplicated
+
+		case poolTypeErasure:
+			pool.Type = models.PoolTypeErasure
+
+			profile, ok := m.ErasureCodeProfiles[p.ErasureCodeProfile]
+			if !ok {
+				return nil, errors.Wrapf(ErrUnexpectedInput, "erasure code profile `%s` of pool `%s` not found", p.ErasureCodeProfile, p.PoolName)
+			}
+
+			k, err := strconv.ParseUint(profile.K, 10, 8)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing k value of erasure code profile `%s`", p.ErasureCodeProfile)
+			}
+
+			m, err := strconv.ParseUint(profile.M, 10, 8)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing m value of erasure code profile `%s`", p.ErasureCodeProfile)
+			}
+
+			pool.ErasureCodeK = uint8(k)
+			pool.ErasureCodeM = uint8(m)
+
+		default:
+			return nil, errors.Wrapf(ErrUnexpectedInput, "unexpected type %d of pool `%s`", p.Type, p.PoolName)
+		}
+
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// crushWeightScale is the scale of CRUSH weights which are 16.16 fixed-point
+// numbers in JSON output
+const crushWeightScale = 0x10000
+
+func parseCRUSHMap(m ReportCRUSHMap) models.CRUSHMap {
+	buckets := []models.CRUSHBucket{}
+	for _, b := range m.Buckets {
+		items := []models.CRUSHBucketItem{}
+		for _, item := range b.Items {
+			items = append(items, models.CRUSHBucketItem{
+				ID:     int32(item.ID),
+				Weight: item.Weight / crushWeightScale,
+			})
+		}
+
+		buckets = append(buckets, models.CRUSHBucket{
+			ID:    int32(b.ID),
+			Name:  b.Name,
+			Type:  b.TypeName,
+			Items: items,
+		})
+	}
+
+	rules := []models.CRUSHRule{}
+	for _, r := range m.Rules {
+		rule := models.CRUSHRule{
+			ID:   r.RuleID,
+			Name: r.RuleName,
+		}
+
+		var (
+			rootFound          bool
+			failureDomainFound bool
+		)
+		for _, step := range r.Steps {
+			switch {
+			case step.Op == "take" && !rootFound:
+				rule.Root = int32(step.Item)
+				rootFound = true
+			case strings.HasPrefix(step.Op, "choose") && !failureDomainFound:
+				rule.FailureDomain = step.Type
+				failureDomainFound = true
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return models.CRUSHMap{
+		Buckets: buckets,
+		Rules:   rules,
+		Tunables: models.CRUSHTunables{
+			Profile:                m.Tunables.Profile,
+			OptimalTunables:        m.Tunables.OptimalTunables == 1,
+			LegacyTunables:         m.Tunables.LegacyTunables == 1,
+			MinimumRequiredVersion: m.Tunables.MinimumRequiredVersion,
+		},
+	}
+}
+
 func parseCephIPAddress(in string) (string, error) {
 	addr := strings.SplitN(in, ":", 2)
 	if len(addr) != 2 {
@@ -1113,6 +1296,50 @@ func parseCephIPAddress(in string) (string, error) {
 	return addressParts[0], nil
 }
 
+// neverDownStamp is the down_stamp value of OSDs which were never marked down
+const neverDownStamp = "0.000000"
+
+func parseOSDStates(m ReportOSDMap) ([]models.OSDState, error) {
+	xinfo := map[int]ReportOSDMapOSDXInfo{}
+	for _, x := range m.OSDXInfo {
+		xinfo[x.OSD] = x
+	}
+
+	states := []models.OSDState{}
+	for _, osd := range m.OSDs {
+		x := xinfo[osd.Osd]
+
+		var downStamp time.Time
+		if x.DownStamp != "" && x.DownStamp != neverDownStamp {
+			var err error
+			downStamp, err = parseTime(x.DownStamp)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing down_stamp of osd.%d", osd.Osd)
+			}
+		}
+
+		states = append(states, models.OSDState{
+			ID:               uint16(osd.Osd),
+			Up:               osd.Up == 1,
+			In:               osd.In == 1,
+			UpFrom:           uint32(osd.UpFrom),
+			DownAt:           uint32(osd.DownAt),
+			DownStamp:        downStamp,
+			LaggyProbability: x.LaggyProbability,
+			LaggyInterval:    time.Duration(x.LaggyInterval) * time.Second,
+		})
+	}
+	return states, nil
+}
+
+// splitList splits comma-separated metadata value, empty value results in nil
+func splitList(in string) []string {
+	if in == "" {
+		return nil
+	}
+	return strings.Split(in, ",")
+}
+
 func countOSDs(osds []ReportOSDMapOSD) (total, up, in, withoutClusterAddress uint16) {
 	for _, osd := range osds {
 		total++
@@ -1132,6 +1359,48 @@ func countOSDs(osds []ReportOSDMapOSD) (total, up, in, withoutClusterAddress uin
 	return
 }
 
+func parseOSDFlags(m ReportOSDMap) models.CephOSDFlags {
+	flags := models.CephOSDFlags{
+		Cluster:       filterFlags(m.FlagsSet, models.ClusterOSDFlags),
+		OSDs:          map[string][]string{},
+		CRUSHNodes:    map[string][]string{},
+		DeviceClasses: map[string][]string{},
+	}
+
+	for _, osd := range m.OSDs {
+		// Per-OSD flags are reported as the part of OSD state
+		if f := filterFlags(osd.State, models.GroupOSDFlags); len(f) > 0 {
+			flags.OSDs["osd."+strconv.Itoa(osd.Osd)] = f
+		}
+	}
+
+	for name, v := range m.CrushNodeFlags {
+		if f := filterFlags(v, models.GroupOSDFlags); len(f) > 0 {
+			flags.CRUSHNodes[name] = f
+		}
+	}
+
+	for name, v := range m.DeviceClassFlags {
+		if f := filterFlags(v, models.GroupOSDFlags); len(f) > 0 {
+			flags.DeviceClasses[name] = f
+		}
+	}
+
+	return flags
+}
+
+// filterFlags returns sorted list of known flags
+func filterFlags(in, known []string) []string {
+	out := []string{}
+	for _, f := range in {
+		if slices.Contains(known, f) && !slices.Contains(out, f) {
+			out = append(out, f)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
 func countOSDsByRelease(osds []ReportOSDMetadata) map[string]uint16 {
 	c := make(map[string]uint16)
 	for _, r := range osds {
@@ -1144,6 +1413,14 @@ func countOSDsByRelease(osds []ReportOSDMetadata) map[string]uint16 {
 	return c
 }
 
+func countRGWsByVersion(daemons ReportServiceMapServicesRgwDaemonGenericMap) map[string]uint16 {
+	c := make(map[string]uint16)
+	for _, d := range daemons {
+		c[d.Metadata.CephVersionShort]++
+	}
+	return c
+}
+
 func countOSDsByVersion(osds []ReportOSDMetadata) map[string]uint16 {
 	c := make(map[string]uint16)
 	for _, r := range osds {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -66,38 +67,617 @@ func TestReportToSvc(t *testing.T) {
 
 	osdDaemons := []models.OSDDaemon{
 		{
-			ID:               0,
-			Hostname:         "nuc01",
-			Architecture:     "x86_64",
-			FrontIP:          "192.168.1.201",
-			BackIP:           "192.168.2.231",
-			MemoryTotalBytes: 65414276,
-			SwapTotalBytes:   0,
-			IsRotational:     false,
+			ID:                 0,
+			Hostname:           "nuc01",
+			Architecture:       "x86_64",
+			KernelVersion:      "6.8.4-2-pve",
+			Distro:             "debian",
+			DistroVersion:      "12",
+			FrontIP:            "192.168.1.201",
+			BackIP:             "192.168.2.231",
+			HeartbeatFrontIP:   "192.168.1.201",
+			HeartbeatBackIP:    "192.168.2.231",
+			CapacityBytes:      892824715264,
+			MemoryTotalBytes:   66984218624,
+			SwapTotalBytes:     0,
+			IsRotational:       false,
+			NUMAUnknownIfaces:  []string{"back_iface", "front_iface"},
+			NUMAUnknownDevices: []string{"sda"},
 			Devices: []string{
 				"sda",
 			},
 		},
 		{
-			ID:               1,
-			Hostname:         "nuc01",
-			Architecture:     "x86_64",
-			FrontIP:          "192.168.1.201",
-			BackIP:           "192.168.2.231",
-			MemoryTotalBytes: 65414276,
-			SwapTotalBytes:   0,
-			IsRotational:     false,
+			ID:                1,
+			Hostname:          "nuc01",
+			Architecture:      "x86_64",
+			KernelVersion:     "6.8.4-2-pve",
+			Distro:            "debian",
+			DistroVersion:     "12",
+			FrontIP:           "192.168.1.201",
+			BackIP:            "192.168.2.231",
+			HeartbeatFrontIP:  "192.168.1.201",
+			HeartbeatBackIP:   "192.168.2.231",
+			CapacityBytes:     1839328133120,
+			MemoryTotalBytes:  66984218624,
+			SwapTotalBytes:    0,
+			IsRotational:      false,
+			NUMAUnknownIfaces: []string{"back_iface", "front_iface"},
 			Devices: []string{
 				"nvme0n1",
 			},
 		},
 	}
 
+	pools := []models.Pool{
+		{
+			ID:        1,
+			Name:      ".mgr",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        2,
+			Name:      "volumes",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        3,
+			Name:      "shared-data_data",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 0,
+		},
+		{
+			ID:        4,
+			Name:      "shared-data_metadata",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        5,
+			Name:      ".rgw.root",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        6,
+			Name:      "default.rgw.log",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        7,
+			Name:      "default.rgw.control",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        8,
+			Name:      "default.rgw.meta",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        9,
+			Name:      "default.rgw.buckets.index",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:        11,
+			Name:      "default.rgw.buckets.non-ec",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 0,
+		},
+		{
+			ID:        24,
+			Name:      "k8s01-pv-replicated",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 3,
+		},
+		{
+			ID:           31,
+			Name:         "volumes-ec-data-4-1-host",
+			Type:         models.PoolTypeErasure,
+			Size:         5,
+			MinSize:      4,
+			CrushRule:    8,
+			ErasureCodeK: 4,
+			ErasureCodeM: 1,
+		},
+		{
+			ID:        32,
+			Name:      "volumes-ec-meta-4-1-host",
+			Type:      models.PoolTypeReplicated,
+			Size:      3,
+			MinSize:   2,
+			CrushRule: 0,
+		},
+		{
+			ID:           48,
+			Name:         "default.rgw.buckets.data",
+			Type:         models.PoolTypeErasure,
+			Size:         5,
+			MinSize:      4,
+			CrushRule:    8,
+			ErasureCodeK: 4,
+			ErasureCodeM: 1,
+		},
+	}
+
+	poolsWithAllocatedBytes := func(allocated map[uint32]uint64) []models.Pool {
+		out := []models.Pool{}
+		for _, p := range pools {
+			p.AllocatedBytes = allocated[p.ID]
+			out = append(out, p)
+		}
+		return out
+	}
+
+	crushMap := models.CRUSHMap{
+		Buckets: []models.CRUSHBucket{
+			{
+				ID:   -1,
+				Name: "default",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -7, Weight: 4.2319793701171875},
+					{ID: -10, Weight: 4.2319793701171875},
+					{ID: -3, Weight: 4.2319793701171875},
+					{ID: -13, Weight: 4.2319793701171875},
+					{ID: -16, Weight: 4.2319793701171875},
+				},
+			},
+			{
+				ID:   -2,
+				Name: "default~ssd",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -8, Weight: 0.8319854736328125},
+					{ID: -11, Weight: 0.8319854736328125},
+					{ID: -4, Weight: 0.8319854736328125},
+					{ID: -14, Weight: 0.8319854736328125},
+					{ID: -17, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -3,
+				Name: "nuc01",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+					{ID: 1, Weight: 1.6999969482421875},
+					{ID: 2, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -4,
+				Name: "nuc01~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -5,
+				Name: "nuc01~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 1, Weight: 1.6999969482421875},
+					{ID: 2, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -6,
+				Name: "default~nvme",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -9, Weight: 3.399993896484375},
+					{ID: -12, Weight: 3.399993896484375},
+					{ID: -5, Weight: 3.399993896484375},
+					{ID: -15, Weight: 3.399993896484375},
+					{ID: -18, Weight: 3.399993896484375},
+				},
+			},
+			{
+				ID:   -7,
+				Name: "nuc02",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 3, Weight: 0.8319854736328125},
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -8,
+				Name: "nuc02~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 3, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -9,
+				Name: "nuc02~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -10,
+				Name: "nuc03",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -11,
+				Name: "nuc03~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -12,
+				Name: "nuc03~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -13,
+				Name: "nuc04",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -14,
+				Name: "nuc04~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -15,
+				Name: "nuc04~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -16,
+				Name: "nuc05",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -17,
+				Name: "nuc05~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -18,
+				Name: "nuc05~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+				},
+			},
+		},
+		Rules: []models.CRUSHRule{
+			{ID: 0, Name: "replicated_rule", Root: -1, FailureDomain: "host"},
+			{ID: 1, Name: "replicated_osd_nvme", Root: -6, FailureDomain: "osd"},
+			{ID: 2, Name: "replicated_osd_ssd", Root: -2, FailureDomain: "osd"},
+			{ID: 3, Name: "replicated_host_nvme", Root: -6, FailureDomain: "host"},
+			{ID: 4, Name: "replicated_host_ssd", Root: -2, FailureDomain: "host"},
+			{ID: 5, Name: "ec-6-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 6, Name: "ec-11-4-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 7, Name: "ec-10-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 8, Name: "ec-4-1-host", Root: -1, FailureDomain: "host"},
+		},
+		Tunables: models.CRUSHTunables{
+			Profile:                "jewel",
+			OptimalTunables:        true,
+			MinimumRequiredVersion: "jewel",
+		},
+	}
+
+	crushMapWithDownOSDs := models.CRUSHMap{
+		Buckets: []models.CRUSHBucket{
+			{
+				ID:   -1,
+				Name: "default",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -7, Weight: 4.2119903564453125},
+					{ID: -10, Weight: 4.2319793701171875},
+					{ID: -3, Weight: 7.52337646484375},
+					{ID: -13, Weight: 4.2319793701171875},
+					{ID: -16, Weight: 4.2319793701171875},
+				},
+			},
+			{
+				ID:   -2,
+				Name: "default~ssd",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -8, Weight: 0.8119964599609375},
+					{ID: -11, Weight: 0.8319854736328125},
+					{ID: -4, Weight: 0.8319854736328125},
+					{ID: -14, Weight: 0.8319854736328125},
+					{ID: -17, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -3,
+				Name: "nuc01",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+					{ID: 1, Weight: 6.6913909912109375},
+				},
+			},
+			{
+				ID:   -4,
+				Name: "nuc01~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 0, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -5,
+				Name: "nuc01~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 1, Weight: 6.6913909912109375},
+				},
+			},
+			{
+				ID:   -6,
+				Name: "default~nvme",
+				Type: "root",
+				Items: []models.CRUSHBucketItem{
+					{ID: -9, Weight: 3.399993896484375},
+					{ID: -12, Weight: 3.399993896484375},
+					{ID: -5, Weight: 6.6913909912109375},
+					{ID: -15, Weight: 3.399993896484375},
+					{ID: -18, Weight: 3.399993896484375},
+				},
+			},
+			{
+				ID:   -7,
+				Name: "nuc02",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+					{ID: 2, Weight: 0.8119964599609375},
+				},
+			},
+			{
+				ID:   -8,
+				Name: "nuc02~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 2, Weight: 0.8119964599609375},
+				},
+			},
+			{
+				ID:   -9,
+				Name: "nuc02~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 4, Weight: 1.6999969482421875},
+					{ID: 5, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -10,
+				Name: "nuc03",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -11,
+				Name: "nuc03~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 6, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -12,
+				Name: "nuc03~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 7, Weight: 1.6999969482421875},
+					{ID: 8, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -13,
+				Name: "nuc04",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -14,
+				Name: "nuc04~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 9, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -15,
+				Name: "nuc04~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 10, Weight: 1.6999969482421875},
+					{ID: 11, Weight: 1.6999969482421875},
+				},
+			},
+			{
+				ID:   -16,
+				Name: "nuc05",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -17,
+				Name: "nuc05~ssd",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 12, Weight: 0.8319854736328125},
+				},
+			},
+			{
+				ID:   -18,
+				Name: "nuc05~nvme",
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: 13, Weight: 1.6999969482421875},
+					{ID: 14, Weight: 1.6999969482421875},
+				},
+			},
+		},
+		Rules: []models.CRUSHRule{
+			{ID: 0, Name: "replicated_rule", Root: -1, FailureDomain: "host"},
+			{ID: 1, Name: "replicated_osd_nvme", Root: -6, FailureDomain: "osd"},
+			{ID: 2, Name: "replicated_osd_ssd", Root: -2, FailureDomain: "osd"},
+			{ID: 3, Name: "replicated_host_nvme", Root: -6, FailureDomain: "host"},
+			{ID: 4, Name: "replicated_host_ssd", Root: -2, FailureDomain: "host"},
+			{ID: 5, Name: "ec-6-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 6, Name: "ec-11-4-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 7, Name: "ec-10-3-osd", Root: -1, FailureDomain: "osd"},
+			{ID: 8, Name: "ec-4-1-host", Root: -1, FailureDomain: "host"},
+		},
+		Tunables: models.CRUSHTunables{
+			Profile:                "jewel",
+			OptimalTunables:        true,
+			MinimumRequiredVersion: "jewel",
+		},
+	}
+
+	cleanOSDStates := []models.OSDState{
+		{ID: 0, Up: true, In: true, UpFrom: 24372, DownAt: 24369, DownStamp: time.Date(2024, 4, 22, 21, 33, 9, 658113000, time.UTC)},
+		{ID: 1, Up: true, In: true, UpFrom: 24926, DownAt: 24925, DownStamp: time.Date(2024, 4, 27, 6, 2, 21, 224403000, time.UTC), LaggyProbability: 0.30000001192092896},
+		{ID: 2, Up: true, In: true, UpFrom: 24462, DownAt: 24369, DownStamp: time.Date(2024, 4, 22, 21, 33, 41, 958226000, time.UTC)},
+		{ID: 3, Up: true, In: true, UpFrom: 24642, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 33, 5, 69120000, time.UTC)},
+		{ID: 4, Up: true, In: true, UpFrom: 24652, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 33, 32, 814547000, time.UTC)},
+		{ID: 5, Up: true, In: true, UpFrom: 24649, DownAt: 24637, DownStamp: time.Date(2024, 4, 22, 21, 34, 12, 11058000, time.UTC)},
+		{ID: 6, Up: true, In: true, UpFrom: 24764, DownAt: 24758, DownStamp: time.Date(2024, 4, 22, 21, 33, 23, 623053000, time.UTC)},
+		{ID: 7, Up: true, In: true, UpFrom: 24762, DownAt: 24757, DownStamp: time.Date(2024, 4, 22, 21, 33, 7, 530156000, time.UTC)},
+		{ID: 8, Up: true, In: true, UpFrom: 24766, DownAt: 24758, DownStamp: time.Date(2024, 4, 22, 21, 33, 26, 55929000, time.UTC)},
+		{ID: 9, Up: true, In: true, UpFrom: 24837, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 19, 23, 51, 209805000, time.UTC)},
+		{ID: 10, Up: true, In: true, UpFrom: 24842, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 21, 32, 47, 630119000, time.UTC)},
+		{ID: 11, Up: true, In: true, UpFrom: 24839, DownAt: 24833, DownStamp: time.Date(2024, 4, 10, 19, 25, 29, 369899000, time.UTC)},
+		{ID: 12, Up: true, In: true, UpFrom: 24877, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 3, 936385000, time.UTC)},
+		{ID: 13, Up: true, In: true, UpFrom: 24879, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 16, 38508000, time.UTC)},
+		{ID: 14, Up: true, In: true, UpFrom: 24886, DownAt: 24873, DownStamp: time.Date(2024, 4, 22, 21, 33, 26, 55929000, time.UTC)},
+	}
+
+	outOSDStates := []models.OSDState{
+		{ID: 0, Up: true, In: true, UpFrom: 27739, DownAt: 27732, DownStamp: time.Date(2024, 5, 15, 15, 21, 12, 248812000, time.UTC), LaggyProbability: 0.8319299817085266, LaggyInterval: 56 * time.Second},
+		{ID: 1, Up: true, In: false, UpFrom: 27580, DownAt: 27578, DownStamp: time.Date(2024, 5, 15, 7, 3, 56, 916991000, time.UTC), LaggyProbability: 0.5099999904632568, LaggyInterval: 4 * time.Second},
+		{ID: 2, Up: true, In: false, UpFrom: 27689, DownAt: 27686, DownStamp: time.Date(2024, 5, 15, 15, 7, 50, 317887000, time.UTC), LaggyProbability: 0.5099999904632568, LaggyInterval: 13 * time.Second},
+		{ID: 3, Up: true, In: true, UpFrom: 27404, DownAt: 27403, DownStamp: time.Date(2024, 5, 10, 18, 22, 35, 421645000, time.UTC)},
+		{ID: 4, Up: true, In: true, UpFrom: 27437, DownAt: 27415, DownStamp: time.Date(2024, 5, 10, 18, 23, 7, 266341000, time.UTC)},
+		{ID: 5, Up: true, In: true, UpFrom: 27789, DownAt: 27787, DownStamp: time.Date(2024, 5, 15, 16, 4, 42, 16679000, time.UTC), LaggyProbability: 0.30000001192092896, LaggyInterval: 3 * time.Second},
+		{ID: 6, Up: true, In: true, UpFrom: 27412, DownAt: 27392, DownStamp: time.Date(2024, 4, 22, 21, 33, 23, 623053000, time.UTC)},
+		{ID: 7, Up: true, In: true, UpFrom: 27454, DownAt: 27452, DownStamp: time.Date(2024, 5, 11, 4, 4, 37, 316027000, time.UTC), LaggyProbability: 0.30000001192092896, LaggyInterval: 5 * time.Second},
+		{ID: 8, Up: true, In: true, UpFrom: 27437, DownAt: 27392, DownStamp: time.Date(2024, 5, 10, 18, 20, 1, 122715000, time.UTC)},
+		{ID: 9, Up: true, In: true, UpFrom: 27408, DownAt: 27407, DownStamp: time.Date(2024, 5, 10, 18, 22, 40, 452805000, time.UTC)},
+		{ID: 10, Up: true, In: true, UpFrom: 27431, DownAt: 27414, DownStamp: time.Date(2024, 5, 10, 18, 23, 6, 58880000, time.UTC)},
+		{ID: 11, Up: true, In: true, UpFrom: 27427, DownAt: 27414, DownStamp: time.Date(2024, 5, 10, 18, 23, 6, 58880000, time.UTC)},
+		{ID: 12, Up: true, In: true, UpFrom: 27407, DownAt: 27406, DownStamp: time.Date(2024, 5, 10, 18, 22, 39, 155478000, time.UTC)},
+		{ID: 13, Up: true, In: true, UpFrom: 27423, DownAt: 27419, DownStamp: time.Date(2024, 5, 10, 18, 23, 41, 566574000, time.UTC)},
+		{ID: 14, Up: true, In: true, UpFrom: 27491, DownAt: 27489, DownStamp: time.Date(2024, 5, 13, 5, 4, 44, 400028000, time.UTC), LaggyProbability: 0.30000001192092896},
+	}
+
+	downOSDStates := []models.OSDState{
+		{ID: 0, Up: true, In: true, UpFrom: 27961, DownAt: 27893, DownStamp: time.Date(2024, 5, 15, 15, 21, 12, 248812000, time.UTC), LaggyProbability: 0.5823509693145752, LaggyInterval: 39 * time.Second},
+		{ID: 1, Up: true, In: true, UpFrom: 28016, DownAt: 0},
+		{ID: 2, Up: true, In: true, UpFrom: 29255, DownAt: 0},
+		{ID: 4, Up: true, In: true, UpFrom: 29825, DownAt: 29822, DownStamp: time.Date(2024, 5, 18, 11, 6, 0, 244725000, time.UTC), LaggyProbability: 0.9952524304389954, LaggyInterval: 13 * time.Second},
+		{ID: 5, Up: true, In: true, UpFrom: 29483, DownAt: 29477, DownStamp: time.Date(2024, 5, 18, 2, 3, 59, 632445000, time.UTC), LaggyProbability: 0.9176456928253174, LaggyInterval: 41 * time.Second},
+		{ID: 6, Up: true, In: true, UpFrom: 30194, DownAt: 30193, DownStamp: time.Date(2024, 5, 19, 15, 49, 32, 973300000, time.UTC), LaggyProbability: 0.30000001192092896},
+		{ID: 7, Up: true, In: true, UpFrom: 29668, DownAt: 29665, DownStamp: time.Date(2024, 5, 18, 7, 6, 40, 806125000, time.UTC), LaggyProbability: 0.7076456546783447, LaggyInterval: 7 * time.Second},
+		{ID: 8, Up: true, In: true, UpFrom: 30140, DownAt: 30137, DownStamp: time.Date(2024, 5, 19, 8, 5, 42, 891700000, time.UTC), LaggyProbability: 0.9176456928253174, LaggyInterval: 19 * time.Second},
+		{ID: 9, Up: false, In: true, UpFrom: 27408, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+		{ID: 10, Up: false, In: true, UpFrom: 27431, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+		{ID: 11, Up: false, In: true, UpFrom: 27427, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+		{ID: 12, Up: false, In: true, UpFrom: 27407, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+		{ID: 13, Up: false, In: true, UpFrom: 27423, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+		{ID: 14, Up: false, In: true, UpFrom: 27491, DownAt: 30191, DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC)},
+	}
+
 	tcs := []testCase{
 		{
 			name: "clean cluster",
 			in:   "testdata/report_samples/CleanReport.json",
 			expOut: models.ClusterReport{
+				CRUSHMap:        crushMap,
 				HealthStatus:    models.ClusterStatusHealthOK,
 				Checks:          []models.ClusterStatusCheck{},
 				MutedChecks:     []models.ClusterStatusMutedCheck{},
@@ -112,6 +692,10 @@ func TestReportToSvc(t *testing.T) {
 				NumOSDsByVersion: map[string]uint16{
 					"18.2.2": 2,
 				},
+				NumDaemonsByVersion: map[string]map[string]uint16{
+					"osd": {"18.2.2": 2},
+					"rgw": {"18.2.2": 5},
+				},
 				NumOSDsByDeviceType: map[string]uint16{
 					"ssd": 2,
 				},
@@ -120,7 +704,23 @@ func TestReportToSvc(t *testing.T) {
 				TotalOSDUsedMetaKB: uint64(512_967_627),
 				TotalOSDUsedOMAPKB: uint64(5_822_580),
 				NumPools:           14,
-				NumPGs:             330,
+				Pools: poolsWithAllocatedBytes(map[uint32]uint64{
+					1:  110309376,
+					2:  103136047104,
+					3:  65968693248,
+					4:  40009728,
+					5:  49152,
+					6:  1977778176,
+					7:  0,
+					8:  909312,
+					9:  0,
+					11: 10149888,
+					24: 15528468480,
+					31: 7483843067904,
+					32: 1683456,
+					48: 3579886067712,
+				}),
+				NumPGs: 330,
 				NumPGsByState: map[string]uint32{
 					"active":        330,
 					"backfill_wait": 50,
@@ -128,18 +728,28 @@ func TestReportToSvc(t *testing.T) {
 					"clean":         278,
 					"remapped":      52,
 				},
-				OSDDaemons:             osdDaemons,
+				OSDDaemons: osdDaemons,
+				OSDStates:  cleanOSDStates,
+				OSDFlags: models.CephOSDFlags{
+					Cluster:       []string{},
+					OSDs:          map[string][]string{},
+					CRUSHNodes:    map[string][]string{},
+					DeviceClasses: map[string][]string{},
+				},
 				AllowCrimson:           false,
 				NearfullRatio:          0.85,
 				BackfillfullRatio:      0.9,
 				FullRatio:              0.95,
-				RequireMinCompatClient: "luminous",
+				RequireMinCompatClient: "reef",
+				RequireOSDRelease:      "reef",
+				MinCompatClient:        "luminous",
 			},
 		},
 		{
 			name: "cluster with OSDs in out state",
 			in:   "testdata/report_samples/ReportWithOutOSDs.json",
 			expOut: models.ClusterReport{
+				CRUSHMap:     crushMap,
 				HealthStatus: models.ClusterStatusHealthWARN,
 				Checks: []models.ClusterStatusCheck{
 					{
@@ -165,6 +775,10 @@ func TestReportToSvc(t *testing.T) {
 				NumOSDsByVersion: map[string]uint16{
 					"18.2.2": 2,
 				},
+				NumDaemonsByVersion: map[string]map[string]uint16{
+					"osd": {"18.2.2": 2},
+					"rgw": {"18.2.2": 4},
+				},
 				NumOSDsByDeviceType: map[string]uint16{
 					"ssd": 2,
 				},
@@ -173,7 +787,23 @@ func TestReportToSvc(t *testing.T) {
 				TotalOSDUsedMetaKB: 489_119_531,
 				TotalOSDUsedOMAPKB: 1_478_996,
 				NumPools:           14,
-				NumPGs:             330,
+				Pools: poolsWithAllocatedBytes(map[uint32]uint64{
+					1:  159137792,
+					2:  181344796672,
+					3:  67348619264,
+					4:  52695040,
+					5:  61440,
+					6:  1359536128,
+					7:  0,
+					8:  991232,
+					9:  0,
+					11: 10489856,
+					24: 16045760512,
+					31: 8984977522688,
+					32: 1880064,
+					48: 2801503191040,
+				}),
+				NumPGs: 330,
 				NumPGsByState: map[string]uint32{
 					"active":           330,
 					"backfill_toofull": 14,
@@ -182,18 +812,28 @@ func TestReportToSvc(t *testing.T) {
 					"clean":            250,
 					"remapped":         153,
 				},
-				OSDDaemons:             osdDaemons,
+				OSDDaemons: osdDaemons,
+				OSDStates:  outOSDStates,
+				OSDFlags: models.CephOSDFlags{
+					Cluster:       []string{"nodown", "noout"},
+					OSDs:          map[string][]string{},
+					CRUSHNodes:    map[string][]string{},
+					DeviceClasses: map[string][]string{},
+				},
 				AllowCrimson:           false,
 				NearfullRatio:          0.85,
 				BackfillfullRatio:      0.9,
 				FullRatio:              0.95,
-				RequireMinCompatClient: "luminous",
+				RequireMinCompatClient: "reef",
+				RequireOSDRelease:      "reef",
+				MinCompatClient:        "luminous",
 			},
 		},
 		{
 			name: "cluster with OSDs in down state",
 			in:   "testdata/report_samples/ReportWithDownOSDs.json",
 			expOut: models.ClusterReport{
+				CRUSHMap:     crushMapWithDownOSDs,
 				HealthStatus: models.ClusterStatusHealthWARN,
 				Checks: []models.ClusterStatusCheck{
 					{
@@ -249,6 +889,10 @@ func TestReportToSvc(t *testing.T) {
 				NumOSDsByVersion: map[string]uint16{
 					"18.2.2": 2,
 				},
+				NumDaemonsByVersion: map[string]map[string]uint16{
+					"osd": {"18.2.2": 2},
+					"rgw": {},
+				},
 				NumOSDsByDeviceType: map[string]uint16{
 					"ssd": 2,
 				},
@@ -257,7 +901,23 @@ func TestReportToSvc(t *testing.T) {
 				TotalOSDUsedMetaKB: 2_194_240_219,
 				TotalOSDUsedOMAPKB: 1_172_260,
 				NumPools:           14,
-				NumPGs:             330,
+				Pools: poolsWithAllocatedBytes(map[uint32]uint64{
+					1:  81534976,
+					2:  73923883008,
+					3:  42734829568,
+					4:  30920704,
+					5:  36864,
+					6:  793874432,
+					7:  0,
+					8:  573440,
+					9:  0,
+					11: 7634944,
+					24: 11195805696,
+					31: 5514784825344,
+					32: 1253376,
+					48: 1689191809024,
+				}),
+				NumPGs: 330,
 				NumPGsByState: map[string]uint32{
 					"active":     118,
 					"clean":      27,
@@ -266,12 +926,21 @@ func TestReportToSvc(t *testing.T) {
 					"peered":     20,
 					"undersized": 111,
 				},
-				OSDDaemons:             osdDaemons,
+				OSDDaemons: osdDaemons,
+				OSDStates:  downOSDStates,
+				OSDFlags: models.CephOSDFlags{
+					Cluster:       []string{},
+					OSDs:          map[string][]string{},
+					CRUSHNodes:    map[string][]string{},
+					DeviceClasses: map[string][]string{},
+				},
 				AllowCrimson:           false,
 				NearfullRatio:          0.85,
 				BackfillfullRatio:      0.9,
 				FullRatio:              0.95,
-				RequireMinCompatClient: "luminous",
+				RequireMinCompatClient: "reef",
+				RequireOSDRelease:      "reef",
+				MinCompatClient:        "luminous",
 			},
 		},
 	}
@@ -294,6 +963,26 @@ func TestReportToSvc(t *testing.T) {
 	}
 }
 
+func TestReportToSvcRequireMinCompatClient(t *testing.T) {
+	r := require.New(t)
+
+	data, err := os.ReadFile("testdata/report_samples/CleanReport.json")
+	r.NoError(err)
+
+	rep := &Report{}
+	err = json.Unmarshal(data, rep)
+	r.NoError(err)
+
+	// min_compat_client is the oldest client release the cluster features
+	// require while require_min_compat_client is the setting itself
+	rep.OSDMap.MinCompatClient = "jewel"
+	rep.OSDMap.RequireMinCompatClient = "reef"
+
+	out, err := rep.ToSvc()
+	r.NoError(err)
+	r.Equal("reef", out.RequireMinCompatClient)
+}
+
 func TestCountOSDs(t *testing.T) {
 	r := require.New(t)
 
@@ -402,6 +1091,120 @@ func TestCountPGs(t *testing.T) {
 	}, byState)
 }
 
+func TestParseOSDFlags(t *testing.T) {
+	r := require.New(t)
+
+	flags := parseOSDFlags(ReportOSDMap{
+		FlagsSet: []string{"noout", "sortbitwise", "noscrub", "pglog_hardlimit"},
+		OSDs: []ReportOSDMapOSD{
+			{Osd: 0, State: []string{"exists", "up"}},
+			{Osd: 1, State: []string{"exists", "up", "noout", "noin"}},
+		},
+		CrushNodeFlags: map[string][]string{
+			"nuc01": {"noout"},
+			"nuc02": {},
+		},
+		DeviceClassFlags: map[string][]string{
+			"ssd": {"nodown"},
+		},
+	})
+	r.Equal(models.CephOSDFlags{
+		Cluster: []string{"noout", "noscrub"},
+		OSDs: map[string][]string{
+			"osd.1": {"noin", "noout"},
+		},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout"},
+		},
+		DeviceClasses: map[string][]string{
+			"ssd": {"nodown"},
+		},
+	}, flags)
+}
+
+func TestParseOSDStates(t *testing.T) {
+	r := require.New(t)
+
+	states, err := parseOSDStates(ReportOSDMap{
+		OSDs: []ReportOSDMapOSD{
+			{Osd: 0, Up: 1, In: 1, UpFrom: 120, DownAt: 118},
+			{Osd: 1, Up: 0, In: 1, UpFrom: 10, DownAt: 125},
+			{Osd: 2, Up: 1, In: 0, UpFrom: 12},
+		},
+		OSDXInfo: []ReportOSDMapOSDXInfo{
+			{OSD: 0, DownStamp: "2024-05-18T11:06:00.244725+0000", LaggyProbability: 0.51, LaggyInterval: 13},
+			{OSD: 1, DownStamp: "2024-05-19T15:49:31.760137+0000"},
+			{OSD: 2, DownStamp: "0.000000"},
+		},
+	})
+	r.NoError(err)
+	r.Equal([]models.OSDState{
+		{
+			ID:               0,
+			Up:               true,
+			In:               true,
+			UpFrom:           120,
+			DownAt:           118,
+			DownStamp:        time.Date(2024, 5, 18, 11, 6, 0, 244725000, time.UTC),
+			LaggyProbability: 0.51,
+			LaggyInterval:    13 * time.Second,
+		},
+		{
+			ID:        1,
+			Up:        false,
+			In:        true,
+			UpFrom:    10,
+			DownAt:    125,
+			DownStamp: time.Date(2024, 5, 19, 15, 49, 31, 760137000, time.UTC),
+		},
+		{
+			ID:     2,
+			Up:     true,
+			In:     false,
+			UpFrom: 12,
+		},
+	}, states)
+}
+
+func TestParsePools(t *testing.T) {
+	r := require.New(t)
+
+	stats := []ReportPoolStats{
+		{Poolid: 2, StoreStats: ReportPoolStatsStoreStats{Allocated: 5_000_000}},
+	}
+
+	pools, err := parsePools(ReportOSDMap{
+		Pools: []ReportOSDMapPool{
+			{Pool: 1, PoolName: ".mgr", Type: 1, Size: 3, MinSize: 2},
+			{Pool: 2, PoolName: "volumes-ec", Type: 3, Size: 5, MinSize: 4, CrushRule: 8, ErasureCodeProfile: "ec-4-1-host"},
+		},
+		ErasureCodeProfiles: map[string]ReportOSDMapErasureCodeProfile{
+			"ec-4-1-host": {K: "4", M: "1"},
+		},
+	}, stats)
+	r.NoError(err)
+	r.Equal([]models.Pool{
+		{ID: 1, Name: ".mgr", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2},
+		{ID: 2, Name: "volumes-ec", Type: models.PoolTypeErasure, Size: 5, MinSize: 4, CrushRule: 8, ErasureCodeK: 4, ErasureCodeM: 1, AllocatedBytes: 5_000_000},
+	}, pools)
+
+	_, err = parsePools(ReportOSDMap{
+		Pools: []ReportOSDMapPool{
+			{Pool: 2, PoolName: "volumes-ec", Type: 3, ErasureCodeProfile: "missing"},
+		},
+	}, nil)
+	r.Error(err)
+	r.Equal("erasure code profile `missing` of pool `volumes-ec` not found: unexpected input", err.Error())
+
+	_, err = parsePools(ReportOSDMap{
+		Pools: []ReportOSDMapPool{
+			{Pool: 1, PoolName: "tier", Type: 2},
+		},
+	}, nil)
+	r.Error(err)
+	r.Equal("unexpected type 2 of pool `tier`: unexpected input", err.Error())
+}
+
 func TestParseCephIPAddress(t *testing.T) {
 	type testCase struct {
 		name     string
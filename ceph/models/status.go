@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -25,6 +26,7 @@ type StatusCheck struct {
 
 type StatusHealthMute struct {
 	Code    string `json:"code"`
+	TTL     string `json:"ttl"`
 	Sticky  bool   `json:"sticky"`
 	Summary string `json:"summary"`
 	Count   int    `json:"count"`
@@ -58,18 +60,20 @@ type PGsInState struct {
 }
 
 type StatusPGMap struct {
-	PgsByState    []PGsInState `json:"pgs_by_state"`
-	NumPgs        int          `json:"num_pgs"`
-	NumPools      int          `json:"num_pools"`
-	NumObjects    int          `json:"num_objects"`
-	DataBytes     int64        `json:"data_bytes"`
-	BytesUsed     int64        `json:"bytes_used"`
-	BytesAvail    int64        `json:"bytes_avail"`
-	BytesTotal    int64        `json:"bytes_total"`
-	ReadBytesSec  int          `json:"read_bytes_sec"`
-	WriteBytesSec int          `json:"write_bytes_sec"`
-	ReadOpPerSec  int          `json:"read_op_per_sec"`
-	WriteOpPerSec int          `json:"write_op_per_sec"`
+	PgsByState     []PGsInState `json:"pgs_by_state"`
+	NumPgs         int          `json:"num_pgs"`
+	NumPools       int          `json:"num_pools"`
+	NumObjects     int          `json:"num_objects"`
+	DataBytes      int64        `json:"data_bytes"`
+	BytesUsed      int64        `json:"bytes_used"`
+	BytesAvail     int64        `json:"bytes_avail"`
+	BytesTotal     int64        `json:"bytes_total"`
+	ReadBytesSec   int          `json:"read_bytes_sec"`
+	WriteBytesSec  int          `json:"write_bytes_sec"`
+	ReadOpPerSec   int          `json:"read_op_per_sec"`
+	WriteOpPerSec  int          `json:"write_op_per_sec"`
+	DegradedRatio  float64      `json:"degraded_ratio"`
+	MisplacedRatio float64      `json:"misplaced_ratio"`
 }
 
 type StatusFSMapByRank struct {
@@ -165,6 +169,13 @@ func (st *Status) ToSvc() (models.ClusterStatus, error) {
 		OSDsDownAmount: uint(osdsDown),
 		UncleanPGs:     uint(st.PGMap.NumPgs) - pgStates["clean"],
 		InactivePGs:    uint(st.PGMap.NumPgs) - pgStates["active"],
+		DegradedRatio:  st.PGMap.DegradedRatio,
+		MisplacedRatio: st.PGMap.MisplacedRatio,
+
+		ClientReadBytesPerSec:  uint64(st.PGMap.ReadBytesSec),
+		ClientWriteBytesPerSec: uint64(st.PGMap.WriteBytesSec),
+		ClientReadOpsPerSec:    uint64(st.PGMap.ReadOpPerSec),
+		ClientWriteOpsPerSec:   uint64(st.PGMap.WriteOpPerSec),
 	}, nil
 }
 
@@ -210,11 +221,41 @@ func NewClusterStatusCheck(in map[string]StatusCheck) ([]models.ClusterStatusChe
 func NewClusterMutedChecks(in []StatusHealthMute) ([]models.ClusterStatusMutedCheck, error) {
 	mutes := []models.ClusterStatusMutedCheck{}
 	for _, m := range in {
+		var expiresAt *time.Time
+		if m.TTL != "" {
+			t, err := parseTime(m.TTL)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing TTL of mute `%s`", m.Code)
+			}
+			expiresAt = &t
+		}
+
 		mutes = append(mutes, models.ClusterStatusMutedCheck{
-			Code:    m.Code,
-			Summary: m.Summary,
+			Code:      m.Code,
+			Summary:   m.Summary,
+			Sticky:    m.Sticky,
+			ExpiresAt: expiresAt,
 		})
 	}
 
 	return mutes, nil
 }
+
+// timeLayouts are the layouts Ceph prints timestamps in: the current one
+// and the legacy one without timezone
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func parseTime(v string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		t, err = time.Parse(layout, v)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, err
+}
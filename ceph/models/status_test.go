@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -44,7 +45,13 @@ func TestStatusMapper(t *testing.T) {
 			NumMons: 5,
 		},
 		PGMap: StatusPGMap{
-			NumPgs: 44,
+			NumPgs:         44,
+			DegradedRatio:  0.0123,
+			MisplacedRatio: 0.25,
+			ReadBytesSec:   15184,
+			WriteBytesSec:  34975,
+			ReadOpPerSec:   16,
+			WriteOpPerSec:  22,
 			PgsByState: []PGsInState{
 				{
 					StateName: "active+degraded",
@@ -78,6 +85,13 @@ func TestStatusMapper(t *testing.T) {
 		OSDsDownAmount: 0,
 		UncleanPGs:     29,
 		InactivePGs:    34,
+		DegradedRatio:  0.0123,
+		MisplacedRatio: 0.25,
+
+		ClientReadBytesPerSec:  15184,
+		ClientWriteBytesPerSec: 34975,
+		ClientReadOpsPerSec:    16,
+		ClientWriteOpsPerSec:   22,
 	}, out)
 }
 
@@ -205,3 +219,34 @@ func TestNewClusterMutedChecks(t *testing.T) {
 		},
 	}, mutes)
 }
+
+func TestNewClusterMutedChecksWithTTL(t *testing.T) {
+	r := require.New(t)
+
+	mutes, err := NewClusterMutedChecks([]StatusHealthMute{
+		{
+			Code:    "OSDMAP_FLAGS",
+			TTL:     "2024-05-14T10:31:12.501289+0000",
+			Sticky:  true,
+			Summary: "noout flag(s) set",
+			Count:   1,
+		},
+	})
+	r.NoError(err)
+	r.Equal([]models.ClusterStatusMutedCheck{
+		{
+			Code:      "OSDMAP_FLAGS",
+			Summary:   "noout flag(s) set",
+			Sticky:    true,
+			ExpiresAt: ptr.Time(time.Date(2024, 5, 14, 10, 31, 12, 501289000, time.UTC)),
+		},
+	}, mutes)
+
+	_, err = NewClusterMutedChecks([]StatusHealthMute{
+		{
+			Code: "OSDMAP_FLAGS",
+			TTL:  "tomorrow",
+		},
+	})
+	r.Error(err)
+}
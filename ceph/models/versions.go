@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+)
+
+// Versions is the output of `ceph versions`: amount of daemons by full
+// version string per daemon type
+type Versions map[string]map[string]uint16
+
+func (v Versions) ToSvc() map[string]map[string]uint16 {
+	out := map[string]map[string]uint16{}
+	for daemonType, versions := range v {
+		if daemonType == "overall" {
+			continue
+		}
+
+		out[daemonType] = map[string]uint16{}
+		for version, n := range versions {
+			out[daemonType][shortVersion(version)] += n
+		}
+	}
+	return out
+}
+
+// shortVersion returns version number from full version string like
+// `ceph version 18.2.2 (e9fe820e7fffd1b7cde143a9f77653b73fcec748) reef (stable)`
+func shortVersion(in string) string {
+	fields := strings.Fields(in)
+	if len(fields) < 3 || fields[0] != "ceph" || fields[1] != "version" {
+		return in
+	}
+	return fields[2]
+}
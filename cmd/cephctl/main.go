@@ -6,15 +6,32 @@ import (
 	"os"
 
 	kingpin "github.com/alecthomas/kingpin/v2"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/runityru/cephctl/ceph"
+	"github.com/runityru/cephctl/ceph/config/options"
+	"github.com/runityru/cephctl/ceph/config/spec"
 	applyCmd "github.com/runityru/cephctl/commands/apply"
 	diffCmd "github.com/runityru/cephctl/commands/diff"
+	dumpCephAuthCmd "github.com/runityru/cephctl/commands/dump/cephauth"
+	dumpCephBalancerCmd "github.com/runityru/cephctl/commands/dump/cephbalancer"
 	dumpCephConfigCmd "github.com/runityru/cephctl/commands/dump/cephconfig"
+	dumpCephFSCmd "github.com/runityru/cephctl/commands/dump/cephfs"
+	dumpCephHealthMutesCmd "github.com/runityru/cephctl/commands/dump/cephhealthmutes"
+	dumpCephMgrModulesCmd "github.com/runityru/cephctl/commands/dump/cephmgrmodules"
 	dumpCephOSDConfigCmd "github.com/runityru/cephctl/commands/dump/cephosdconfig"
+	dumpCephOSDFlagsCmd "github.com/runityru/cephctl/commands/dump/cephosdflags"
 	healthcheckCmd "github.com/runityru/cephctl/commands/healthcheck"
+	maintenanceEndCmd "github.com/runityru/cephctl/commands/maintenance/end"
+	maintenanceStartCmd "github.com/runityru/cephctl/commands/maintenance/start"
+	okToStopCmd "github.com/runityru/cephctl/commands/oktostop"
+	recommendMemoryCmd "github.com/runityru/cephctl/commands/recommend/memory"
+	renderCmd "github.com/runityru/cephctl/commands/render"
+	schemaCmd "github.com/runityru/cephctl/commands/schema"
+	validateCmd "github.com/runityru/cephctl/commands/validate"
 	"github.com/runityru/cephctl/differ"
+	"github.com/runityru/cephctl/models"
 	"github.com/runityru/cephctl/printer"
 	"github.com/runityru/cephctl/service"
 )
@@ -51,19 +68,75 @@ var (
 			Default("true").
 			Bool()
 
+	cephRelease = app.
+			Flag("ceph-release", "Ceph release to validate specifications against").
+			Short('r').
+			Envar("CEPHCTL_CEPH_RELEASE").
+			Default("reef").
+			String()
+
+	optionSchemaFile = app.
+				Flag("option-schema", "Path to option schema file to use instead of bundled one").
+				Envar("CEPHCTL_OPTION_SCHEMA").
+				String()
+
+	valuesFile = app.
+			Flag("values", "Path to YAML file with values for specification templates").
+			Envar("CEPHCTL_VALUES").
+			String()
+
 	apply         = app.Command("apply", "Apply ceph configuration")
-	applySpecFile = apply.Arg("filename", "Filename with configuration specification").Required().String()
+	applySpecFile = apply.Arg("filename", "Filename, directory or glob pattern with configuration specification").Required().String()
+	applyValidate = apply.Flag("validate", "Validate specification against option schema before applying").Default("true").Bool()
 
 	diff = app.Command("diff", "Show difference between running and desired configurations")
 
-	diffSpecFile = diff.Arg("filename", "Filename with configuration specification").Required().String()
+	diffSpecFile = diff.Arg("filename", "Filename, directory or glob pattern with configuration specification").Required().String()
+
+	dump                = app.Command("dump", "Dump runtime configuration")
+	dumpCephAuth        = dump.Command("cephauth", "dump CephX entities and their caps")
+	dumpCephBalancer    = dump.Command("cephbalancer", "dump Ceph balancer configuration")
+	dumpCephConfig      = dump.Command("cephconfig", "dump Ceph runtime configuration")
+	dumpCephFS          = dump.Command("cephfs", "dump CephFS filesystems settings")
+	dumpCephHealthMutes = dump.Command("cephhealthmutes", "dump Ceph health check mutes")
+	dumpCephMgrModules  = dump.Command("cephmgrmodules", "dump Ceph manager modules")
+	dumpCephOSDConfig   = dump.Command("cephosdconfig", "dump Ceph OSD configuration")
+	dumpCephOSDFlags    = dump.Command("cephosdflags", "dump Ceph OSD flags")
 
-	dump              = app.Command("dump", "Dump runtime configuration")
-	dumpCephConfig    = dump.Command("cephconfig", "dump Ceph runtime configuration")
-	dumpCephOSDConfig = dump.Command("cephosdconfig", "dump Ceph OSD configuration")
+	dumpCephAuthShowSecrets = dumpCephAuth.Flag("show-secrets", "Include entity keys into the output").Bool()
 
 	healthcheck = app.Command("healthcheck", "Perform a cluster healthcheck and print report")
 
+	maintenance                = app.Command("maintenance", "Start and end maintenance of the host or OSD")
+	maintenanceStart           = maintenance.Command("start", "Check preconditions, set noout and norebalance flags and persist maintenance lease")
+	maintenanceStartHost       = maintenanceStart.Flag("host", "CRUSH host to start maintenance of").String()
+	maintenanceStartOSD        = maintenanceStart.Flag("osd", "OSD ID to start maintenance of").PlaceHolder("ID").String()
+	maintenanceStartTTL        = maintenanceStart.Flag("ttl", "Maintenance lease duration, healthcheck reports expired lease as forgotten maintenance").Default("4h").Duration()
+	maintenanceStartForce      = maintenanceStart.Flag("force", "Start maintenance even if preconditions failed").Bool()
+	maintenanceEnd             = maintenance.Command("end", "Restore flags set for maintenance and wait for PGs to become active+clean")
+	maintenanceEndWait         = maintenanceEnd.Flag("wait", "Wait for PGs to become active+clean").Default("true").Bool()
+	maintenanceEndWaitTimeout  = maintenanceEnd.Flag("wait-timeout", "Maximum amount of time to wait for PGs").Default("1h").Duration()
+	maintenanceEndPollInterval = maintenanceEnd.Flag("poll-interval", "Interval to check PG states at").Default("10s").Duration()
+
+	okToStop        = app.Command("ok-to-stop", "Check whether the host or OSD could be stopped and print go/no-go answer with the reasons")
+	okToStopHost    = okToStop.Flag("host", "Host to check OSDs of").String()
+	okToStopOSD     = okToStop.Flag("osd", "OSD ID to check").PlaceHolder("ID").String()
+	okToStopDestroy = okToStop.Flag("destroy", "Require OSDs to be safe to destroy (i.e. for disk replacement)").Bool()
+
+	recommend               = app.Command("recommend", "Print recommended configuration computed from the cluster state")
+	recommendMemory         = recommend.Command("memory", "Print recommended osd_memory_target for each host as CephConfig specification")
+	recommendMemoryReserved = recommendMemory.Flag("reserved", "Amount of memory to reserve for OS and other daemons on each host").Default("4GiB").Bytes()
+	recommendMemoryHeadroom = recommendMemory.Flag("headroom", "Ratio of memory to leave for OSDs exceeding the target").Default("0.2").Float64()
+
+	render         = app.Command("render", "Print specification with includes and patches resolved")
+	renderSpecFile = render.Arg("filename", "Filename, directory or glob pattern with configuration specification").Required().String()
+
+	schema     = app.Command("schema", "Print JSON schema for specification documents")
+	schemaKind = schema.Arg("kind", "Specification kind to print schema for (all kinds if omitted)").String()
+
+	validate         = app.Command("validate", "Validate configuration specification against Ceph option schema")
+	validateSpecFile = validate.Arg("filename", "Filename, directory or glob pattern with configuration specification").Required().String()
+
 	version = app.Command("version", "Print version and exit")
 )
 
@@ -91,9 +164,21 @@ func main() {
 	switch appCmd {
 	case apply.FullCommand():
 		log.Debug("running apply command")
+
+		var schema *options.Schema
+		if *applyValidate {
+			var err error
+			schema, err = loadOptionSchema()
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		if err := applyCmd.Apply(ctx, applyCmd.ApplyConfig{
-			Service:  svc,
-			SpecFile: *applySpecFile,
+			Service:      svc,
+			SpecFile:     *applySpecFile,
+			SpecOptions:  specOptions(ctx, svc),
+			OptionSchema: schema,
 		}); err != nil {
 			panic(err)
 		}
@@ -101,9 +186,29 @@ func main() {
 	case diff.FullCommand():
 		log.Debug("running diff command")
 		if err := diffCmd.Diff(ctx, diffCmd.DiffConfig{
-			Printer:  prntr,
-			Service:  svc,
-			SpecFile: *diffSpecFile,
+			Printer:     prntr,
+			Service:     svc,
+			SpecFile:    *diffSpecFile,
+			SpecOptions: specOptions(ctx, svc),
+		}); err != nil {
+			panic(err)
+		}
+
+	case dumpCephAuth.FullCommand():
+		log.Debug("running dump cephauth command")
+		if err := dumpCephAuthCmd.DumpCephAuth(ctx, dumpCephAuthCmd.DumpCephAuthConfig{
+			Printer:     prntr,
+			Service:     svc,
+			ShowSecrets: *dumpCephAuthShowSecrets,
+		}); err != nil {
+			panic(err)
+		}
+
+	case dumpCephBalancer.FullCommand():
+		log.Debug("running dump cephbalancer command")
+		if err := dumpCephBalancerCmd.DumpCephBalancer(ctx, dumpCephBalancerCmd.DumpCephBalancerConfig{
+			Printer: prntr,
+			Service: svc,
 		}); err != nil {
 			panic(err)
 		}
@@ -117,6 +222,33 @@ func main() {
 			panic(err)
 		}
 
+	case dumpCephFS.FullCommand():
+		log.Debug("running dump cephfs command")
+		if err := dumpCephFSCmd.DumpCephFS(ctx, dumpCephFSCmd.DumpCephFSConfig{
+			Printer: prntr,
+			Service: svc,
+		}); err != nil {
+			panic(err)
+		}
+
+	case dumpCephHealthMutes.FullCommand():
+		log.Debug("running dump cephhealthmutes command")
+		if err := dumpCephHealthMutesCmd.DumpCephHealthMutes(ctx, dumpCephHealthMutesCmd.DumpCephHealthMutesConfig{
+			Printer: prntr,
+			Service: svc,
+		}); err != nil {
+			panic(err)
+		}
+
+	case dumpCephMgrModules.FullCommand():
+		log.Debug("running dump cephmgrmodules command")
+		if err := dumpCephMgrModulesCmd.DumpCephMgrModules(ctx, dumpCephMgrModulesCmd.DumpCephMgrModulesConfig{
+			Printer: prntr,
+			Service: svc,
+		}); err != nil {
+			panic(err)
+		}
+
 	case dumpCephOSDConfig.FullCommand():
 		log.Debug("running dump cephosdconfig command")
 		if err := dumpCephOSDConfigCmd.DumpCephOSDConfig(ctx, dumpCephOSDConfigCmd.DumpCephOSDConfigConfig{
@@ -126,6 +258,15 @@ func main() {
 			panic(err)
 		}
 
+	case dumpCephOSDFlags.FullCommand():
+		log.Debug("running dump cephosdflags command")
+		if err := dumpCephOSDFlagsCmd.DumpCephOSDFlags(ctx, dumpCephOSDFlagsCmd.DumpCephOSDFlagsConfig{
+			Printer: prntr,
+			Service: svc,
+		}); err != nil {
+			panic(err)
+		}
+
 	case healthcheck.FullCommand():
 		if err := healthcheckCmd.Healthcheck(ctx, healthcheckCmd.HealthcheckConfig{
 			Printer: prntr,
@@ -134,6 +275,93 @@ func main() {
 			panic(err)
 		}
 
+	case maintenanceStart.FullCommand():
+		log.Debug("running maintenance start command")
+		if err := maintenanceStartCmd.Start(ctx, maintenanceStartCmd.StartConfig{
+			Printer: prntr,
+			Service: svc,
+			Host:    *maintenanceStartHost,
+			OSD:     *maintenanceStartOSD,
+			TTL:     *maintenanceStartTTL,
+			Force:   *maintenanceStartForce,
+		}); err != nil {
+			panic(err)
+		}
+
+	case maintenanceEnd.FullCommand():
+		log.Debug("running maintenance end command")
+		if err := maintenanceEndCmd.End(ctx, maintenanceEndCmd.EndConfig{
+			Printer:      prntr,
+			Service:      svc,
+			Wait:         *maintenanceEndWait,
+			WaitTimeout:  *maintenanceEndWaitTimeout,
+			PollInterval: *maintenanceEndPollInterval,
+		}); err != nil {
+			panic(err)
+		}
+
+	case okToStop.FullCommand():
+		log.Debug("running ok-to-stop command")
+		if err := okToStopCmd.OkToStop(ctx, okToStopCmd.OkToStopConfig{
+			Printer: prntr,
+			Service: svc,
+			Host:    *okToStopHost,
+			OSD:     *okToStopOSD,
+			Destroy: *okToStopDestroy,
+		}); err != nil {
+			if errors.Is(err, okToStopCmd.ErrNoGo) {
+				os.Exit(1)
+			}
+			panic(err)
+		}
+
+	case recommendMemory.FullCommand():
+		log.Debug("running recommend memory command")
+		if err := recommendMemoryCmd.RecommendMemory(ctx, recommendMemoryCmd.RecommendMemoryConfig{
+			Printer:       prntr,
+			Service:       svc,
+			ReservedBytes: uint64(*recommendMemoryReserved),
+			Headroom:      *recommendMemoryHeadroom,
+		}); err != nil {
+			panic(err)
+		}
+
+	case render.FullCommand():
+		log.Debug("running render command")
+		if err := renderCmd.Render(ctx, renderCmd.RenderConfig{
+			Printer:     prntr,
+			SpecFile:    *renderSpecFile,
+			SpecOptions: specOptions(ctx, svc),
+		}); err != nil {
+			panic(err)
+		}
+
+	case schema.FullCommand():
+		log.Debug("running schema command")
+		if err := schemaCmd.Schema(ctx, schemaCmd.SchemaConfig{
+			Printer: prntr,
+			Kind:    *schemaKind,
+		}); err != nil {
+			panic(err)
+		}
+
+	case validate.FullCommand():
+		log.Debug("running validate command")
+
+		schema, err := loadOptionSchema()
+		if err != nil {
+			panic(err)
+		}
+
+		if err := validateCmd.Validate(ctx, validateCmd.ValidateConfig{
+			Printer:      prntr,
+			OptionSchema: schema,
+			SpecFile:     *validateSpecFile,
+			SpecOptions:  specOptions(ctx, svc),
+		}); err != nil {
+			panic(err)
+		}
+
 	case version.FullCommand():
 		fmt.Printf(
 			"%s v%s / built at %s\n",
@@ -142,3 +370,28 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func loadOptionSchema() (*options.Schema, error) {
+	if *optionSchemaFile != "" {
+		return options.LoadFile(*optionSchemaFile)
+	}
+	return options.Load(*cephRelease)
+}
+
+func specOptions(ctx context.Context, svc service.Service) []spec.Option {
+	opts := []spec.Option{
+		spec.WithFacts(func() (models.ClusterFacts, error) {
+			return svc.ClusterFacts(ctx)
+		}),
+	}
+
+	if *valuesFile != "" {
+		values, err := spec.LoadValues(*valuesFile)
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, spec.WithValues(values))
+	}
+
+	return opts
+}
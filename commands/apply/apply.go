@@ -5,24 +5,45 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
+	"github.com/runityru/cephctl/ceph/config/options"
 	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/ceph/config/spec/cephauth"
+	"github.com/runityru/cephctl/ceph/config/spec/cephbalancer"
 	"github.com/runityru/cephctl/ceph/config/spec/cephconfig"
+	"github.com/runityru/cephctl/ceph/config/spec/cephfs"
+	"github.com/runityru/cephctl/ceph/config/spec/cephhealthmutes"
+	"github.com/runityru/cephctl/ceph/config/spec/cephmgrmodules"
 	"github.com/runityru/cephctl/ceph/config/spec/cephosdconfig"
+	"github.com/runityru/cephctl/ceph/config/spec/cephosdflags"
 	"github.com/runityru/cephctl/service"
 )
 
+var ErrValidationFailed = errors.New("specification validation failed")
+
 type ApplyConfig struct {
-	Service  service.Service
-	SpecFile string
+	Service     service.Service
+	SpecFile    string
+	SpecOptions []spec.Option
+
+	// OptionSchema is used to validate specification before applying
+	// any changes. Validation is skipped when not set.
+	OptionSchema *options.Schema
 }
 
 func Apply(ctx context.Context, ac ApplyConfig) error {
-	descs, err := spec.NewFromDescription(ac.SpecFile)
+	descs, err := spec.NewFromDescription(ac.SpecFile, ac.SpecOptions...)
 	if err != nil {
 		return err
 	}
 
+	if ac.OptionSchema != nil {
+		if err := preflight(ac.OptionSchema, descs); err != nil {
+			return err
+		}
+	}
+
 	for _, desc := range descs {
 		switch strings.ToLower(desc.Kind) {
 		case "cephconfig":
@@ -45,6 +66,66 @@ func Apply(ctx context.Context, ac ApplyConfig) error {
 				return err
 			}
 
+		case "cephosdflags":
+			flags, err := cephosdflags.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephOSDFlags(ctx, flags); err != nil {
+				return err
+			}
+
+		case "cephmgrmodules":
+			modules, err := cephmgrmodules.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephMgrModules(ctx, modules); err != nil {
+				return err
+			}
+
+		case "cephbalancer":
+			cfg, err := cephbalancer.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephBalancer(ctx, cfg); err != nil {
+				return err
+			}
+
+		case "cephhealthmutes":
+			mutes, err := cephhealthmutes.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephHealthMutes(ctx, mutes); err != nil {
+				return err
+			}
+
+		case "cephauth":
+			auth, err := cephauth.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephAuth(ctx, auth); err != nil {
+				return err
+			}
+
+		case "cephfs":
+			fs, err := cephfs.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			if err := ac.Service.ApplyCephFS(ctx, fs); err != nil {
+				return err
+			}
+
 		default:
 			return errors.Errorf("unexpected specification kind: `%s`", desc.Kind)
 		}
@@ -52,3 +133,25 @@ func Apply(ctx context.Context, ac ApplyConfig) error {
 
 	return nil
 }
+
+func preflight(schema *options.Schema, descs []spec.Description) error {
+	issues, err := schema.Validate(descs)
+	if err != nil {
+		return err
+	}
+
+	errs := []string{}
+	for _, issue := range issues {
+		if issue.Severity == options.SeverityError {
+			errs = append(errs, issue.String())
+			continue
+		}
+
+		log.Warn(issue.String())
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrap(ErrValidationFailed, strings.Join(errs, "; "))
+	}
+	return nil
+}
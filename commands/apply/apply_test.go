@@ -3,9 +3,12 @@ package apply
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/teran/go-ptr"
 
+	"github.com/runityru/cephctl/ceph/config/options"
 	"github.com/runityru/cephctl/models"
 	"github.com/runityru/cephctl/service"
 )
@@ -49,3 +52,144 @@ func TestApplyCephOSDConfig(t *testing.T) {
 	})
 	r.NoError(err)
 }
+
+func TestApplyCephOSDFlags(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephOSDFlags", models.CephOSDFlags{
+		Cluster: []string{"noscrub"},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout"},
+		},
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephosdflags.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyCephMgrModules(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephMgrModules", models.CephMgrModules{
+		Enabled:  []string{"prometheus"},
+		Disabled: []string{"dashboard"},
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephmgrmodules.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyCephBalancer(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephBalancer", models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 1,
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephbalancer.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyCephHealthMutes(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephHealthMutes", models.CephHealthMutes{
+		"OSDMAP_FLAGS": {
+			TTL:           4 * time.Hour,
+			Sticky:        true,
+			Justification: "maintenance of nuc01",
+		},
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephhealthmutes.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyCephAuth(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephAuth", models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+			},
+		},
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephauth.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyCephFS(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	m.On("ApplyCephFS", models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(2),
+			AllowStandbyReplay: ptr.Bool(true),
+		},
+	}).Return(nil).Once()
+
+	err := Apply(context.Background(), ApplyConfig{
+		Service:  m,
+		SpecFile: "testdata/cephfs.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestApplyValidationFailed(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	s, err := options.Load("reef")
+	r.NoError(err)
+
+	err = Apply(context.Background(), ApplyConfig{
+		Service:      m,
+		SpecFile:     "testdata/cephconfig_invalid.yaml",
+		OptionSchema: s,
+	})
+	r.ErrorIs(err, ErrValidationFailed)
+	r.Equal(
+		"testdata/cephconfig_invalid.yaml:5:5: error: invalid value for `osd_scrub_begin_hour` (int): `25` is greater than maximal allowed value 23: specification validation failed",
+		err.Error(),
+	)
+}
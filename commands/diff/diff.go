@@ -2,27 +2,35 @@ package diff
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/ceph/config/spec/cephauth"
+	"github.com/runityru/cephctl/ceph/config/spec/cephbalancer"
 	"github.com/runityru/cephctl/ceph/config/spec/cephconfig"
+	"github.com/runityru/cephctl/ceph/config/spec/cephfs"
+	"github.com/runityru/cephctl/ceph/config/spec/cephhealthmutes"
+	"github.com/runityru/cephctl/ceph/config/spec/cephmgrmodules"
 	"github.com/runityru/cephctl/ceph/config/spec/cephosdconfig"
+	"github.com/runityru/cephctl/ceph/config/spec/cephosdflags"
 	"github.com/runityru/cephctl/models"
 	"github.com/runityru/cephctl/printer"
 	"github.com/runityru/cephctl/service"
 )
 
 type DiffConfig struct {
-	Service  service.Service
-	Printer  printer.Printer
-	SpecFile string
+	Service     service.Service
+	Printer     printer.Printer
+	SpecFile    string
+	SpecOptions []spec.Option
 }
 
 func Diff(ctx context.Context, ac DiffConfig) error {
-	descs, err := spec.NewFromDescription(ac.SpecFile)
+	descs, err := spec.NewFromDescription(ac.SpecFile, ac.SpecOptions...)
 	if err != nil {
 		return err
 	}
@@ -51,11 +59,11 @@ func Diff(ctx context.Context, ac DiffConfig) error {
 
 				switch change.Kind {
 				case models.CephConfigDifferenceKindAdd:
-					ac.Printer.Green("+ %s %s %s", change.Section, change.Key, *change.Value)
+					ac.Printer.Green("+ %s %s %s", change.Target(), change.Key, *change.Value)
 				case models.CephConfigDifferenceKindChange:
-					ac.Printer.Yellow("~ %s %s %s -> %s", change.Section, change.Key, *change.OldValue, *change.Value)
+					ac.Printer.Yellow("~ %s %s %s -> %s", change.Target(), change.Key, *change.OldValue, *change.Value)
 				case models.CephConfigDifferenceKindRemove:
-					ac.Printer.Red("- %s %s", change.Section, change.Key)
+					ac.Printer.Red("- %s %s", change.Target(), change.Key)
 				}
 			}
 
@@ -82,6 +90,173 @@ func Diff(ctx context.Context, ac DiffConfig) error {
 				ac.Printer.Yellow("~ %s %s -> %s", change.Key, change.OldValue, change.Value)
 			}
 
+		case "cephosdflags":
+			flags, err := cephosdflags.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephOSDFlags(ctx, flags)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				who := change.Who
+				if who == "" {
+					who = "cluster"
+				}
+
+				switch change.Kind {
+				case models.CephOSDFlagsDifferenceKindSet:
+					ac.Printer.Green("+ %s %s", who, change.Flag)
+				case models.CephOSDFlagsDifferenceKindUnset:
+					ac.Printer.Red("- %s %s", who, change.Flag)
+				}
+			}
+
+		case "cephmgrmodules":
+			modules, err := cephmgrmodules.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephMgrModules(ctx, modules)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				switch change.Kind {
+				case models.CephMgrModulesDifferenceKindEnable:
+					ac.Printer.Green("+ %s", change.Module)
+				case models.CephMgrModulesDifferenceKindDisable:
+					ac.Printer.Red("- %s", change.Module)
+				}
+			}
+
+		case "cephbalancer":
+			cfg, err := cephbalancer.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephBalancer(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				ac.Printer.Yellow("~ %s %s -> %s", change.Key, change.OldValue, change.Value)
+			}
+
+		case "cephhealthmutes":
+			mutes, err := cephhealthmutes.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephHealthMutes(ctx, mutes)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				switch change.Kind {
+				case models.CephHealthMutesDifferenceKindMute:
+					ac.Printer.Green("+ %s %s", change.Code, muteDescription(change.Mute))
+				case models.CephHealthMutesDifferenceKindChange:
+					ac.Printer.Yellow("~ %s %s", change.Code, muteDescription(change.Mute))
+				case models.CephHealthMutesDifferenceKindUnmute:
+					ac.Printer.Red("- %s (unexpected)", change.Code)
+				}
+			}
+
+		case "cephauth":
+			auth, err := cephauth.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephAuth(ctx, auth)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				switch change.Kind {
+				case models.CephAuthDifferenceKindAdd:
+					ac.Printer.Green("+ %s %s %q", change.Entity, change.Daemon, change.Caps)
+				case models.CephAuthDifferenceKindChange:
+					ac.Printer.Yellow("~ %s %s %q -> %q", change.Entity, change.Daemon, change.OldCaps, change.Caps)
+				case models.CephAuthDifferenceKindRemove:
+					ac.Printer.Red("- %s %s", change.Entity, change.Daemon)
+				}
+			}
+
+		case "cephfs":
+			fs, err := cephfs.New(desc.Spec)
+			if err != nil {
+				return err
+			}
+
+			changes, err := ac.Service.DiffCephFS(ctx, fs)
+			if err != nil {
+				return err
+			}
+
+			if len(descs) > 1 && len(changes) > 0 {
+				ac.Printer.Printf("%s:\n", desc.Kind)
+			}
+
+			for _, change := range changes {
+				log.WithFields(log.Fields{
+					"component": "command",
+				}).Tracef("change: %#v", change)
+
+				ac.Printer.Yellow("~ %s %s %s -> %s", change.FileSystem, change.Key, change.OldValue, change.Value)
+			}
+
 		default:
 			return errors.Errorf("unexpected specification kind: `%s`", desc.Kind)
 		}
@@ -89,3 +264,19 @@ func Diff(ctx context.Context, ac DiffConfig) error {
 
 	return nil
 }
+
+func muteDescription(m models.CephHealthMute) string {
+	attrs := []string{}
+	if m.TTL > 0 {
+		attrs = append(attrs, "ttl="+m.TTL.String())
+	} else {
+		attrs = append(attrs, "permanent")
+	}
+	if m.Sticky {
+		attrs = append(attrs, "sticky")
+	}
+	if m.Justification != "" {
+		attrs = append(attrs, strconv.Quote(m.Justification))
+	}
+	return strings.Join(attrs, " ")
+}
@@ -3,6 +3,7 @@ package diff
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/teran/go-ptr"
@@ -44,11 +45,20 @@ func TestDiffCephConfig(t *testing.T) {
 			Section: "osd",
 			Key:     "test_key",
 		},
+		{
+			Kind:     models.CephConfigDifferenceKindChange,
+			Section:  "osd",
+			Mask:     "class:ssd",
+			Key:      "test_key",
+			OldValue: ptr.String("old_value"),
+			Value:    ptr.String("value"),
+		},
 	}, nil).Once()
 
 	p.On("Green", "+ %s %s %s", []any{"mon", "test_key", "value"}).Return().Once()
 	p.On("Yellow", "~ %s %s %s -> %s", []any{"osd.3", "test_key", "old_value", "value"}).Return().Once()
 	p.On("Red", "- %s %s", []any{"osd", "test_key"}).Return().Once()
+	p.On("Yellow", "~ %s %s %s -> %s", []any{"osd/class:ssd", "test_key", "old_value", "value"}).Return().Once()
 
 	err := Diff(context.Background(), DiffConfig{
 		Printer:  p,
@@ -91,6 +101,191 @@ func TestDiffCephOSDConfig(t *testing.T) {
 	r.NoError(err)
 }
 
+func TestDiffCephOSDFlags(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DiffCephOSDFlags", models.CephOSDFlags{
+		Cluster: []string{"noscrub"},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout"},
+		},
+	}).Return([]models.CephOSDFlagsDifference{
+		{Kind: models.CephOSDFlagsDifferenceKindSet, Flag: "noscrub"},
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "noout"},
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Who: "osd.3", Flag: "noout"},
+	}, nil).Once()
+
+	call1 := p.On("Green", "+ %s %s", []any{"cluster", "noscrub"}).Return().Once()
+	call2 := p.On("Red", "- %s %s", []any{"cluster", "noout"}).Return().NotBefore(call1).Once()
+	p.On("Red", "- %s %s", []any{"osd.3", "noout"}).Return().NotBefore(call2).Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephosdflags.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestDiffCephMgrModules(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DiffCephMgrModules", models.CephMgrModules{
+		Enabled:  []string{"prometheus"},
+		Disabled: []string{"dashboard"},
+	}).Return([]models.CephMgrModulesDifference{
+		{Kind: models.CephMgrModulesDifferenceKindEnable, Module: "prometheus"},
+		{Kind: models.CephMgrModulesDifferenceKindDisable, Module: "dashboard"},
+	}, nil).Once()
+
+	call1 := p.On("Green", "+ %s", []any{"prometheus"}).Return().Once()
+	p.On("Red", "- %s", []any{"dashboard"}).Return().NotBefore(call1).Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephmgrmodules.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestDiffCephBalancer(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DiffCephBalancer", models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 1,
+	}).Return([]models.CephBalancerDifference{
+		{Key: "upmap_max_deviation", OldValue: "5", Value: "1"},
+	}, nil).Once()
+
+	p.On("Yellow", "~ %s %s -> %s", []any{"upmap_max_deviation", "5", "1"}).Return().Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephbalancer.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestDiffCephHealthMutes(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	mute := models.CephHealthMute{
+		TTL:           4 * time.Hour,
+		Sticky:        true,
+		Justification: "maintenance of nuc01",
+	}
+	expiresAt := time.Date(2024, 5, 14, 10, 31, 12, 0, time.UTC)
+
+	m.On("DiffCephHealthMutes", models.CephHealthMutes{
+		"OSDMAP_FLAGS": mute,
+	}).Return([]models.CephHealthMutesDifference{
+		{Kind: models.CephHealthMutesDifferenceKindMute, Code: "OSDMAP_FLAGS", Mute: mute},
+		{Kind: models.CephHealthMutesDifferenceKindChange, Code: "OSD_NEARFULL", Mute: models.CephHealthMute{TTL: time.Hour}, ExpiresAt: &expiresAt},
+		{Kind: models.CephHealthMutesDifferenceKindUnmute, Code: "POOL_NO_REDUNDANCY"},
+	}, nil).Once()
+
+	call1 := p.On("Green", "+ %s %s", []any{"OSDMAP_FLAGS", `ttl=4h0m0s sticky "maintenance of nuc01"`}).Return().Once()
+	call2 := p.On("Yellow", "~ %s %s", []any{"OSD_NEARFULL", "ttl=1h0m0s"}).Return().NotBefore(call1).Once()
+	p.On("Red", "- %s (unexpected)", []any{"POOL_NO_REDUNDANCY"}).Return().NotBefore(call2).Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephhealthmutes.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestDiffCephAuth(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DiffCephAuth", models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+			},
+		},
+	}).Return([]models.CephAuthDifference{
+		{Kind: models.CephAuthDifferenceKindRemove, Entity: "client.rbd-prod", Daemon: "mds", OldCaps: "allow"},
+		{Kind: models.CephAuthDifferenceKindAdd, Entity: "client.rbd-prod", Daemon: "mon", Caps: "profile rbd"},
+		{Kind: models.CephAuthDifferenceKindChange, Entity: "client.rbd-prod", Daemon: "osd", OldCaps: "allow rwx", Caps: "profile rbd pool=rbd"},
+	}, nil).Once()
+
+	call1 := p.On("Red", "- %s %s", []any{"client.rbd-prod", "mds"}).Return().Once()
+	call2 := p.On("Green", "+ %s %s %q", []any{"client.rbd-prod", "mon", "profile rbd"}).Return().NotBefore(call1).Once()
+	p.On("Yellow", "~ %s %s %q -> %q", []any{"client.rbd-prod", "osd", "allow rwx", "profile rbd pool=rbd"}).Return().NotBefore(call2).Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephauth.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestDiffCephFS(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DiffCephFS", models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(2),
+			AllowStandbyReplay: ptr.Bool(true),
+		},
+	}).Return([]models.CephFSDifference{
+		{FileSystem: "shared-data", Key: "max_mds", OldValue: "1", Value: "2"},
+	}, nil).Once()
+
+	p.On("Yellow", "~ %s %s %s -> %s", []any{"shared-data", "max_mds", "1", "2"}).Return().Once()
+
+	err := Diff(context.Background(), DiffConfig{
+		Printer:  p,
+		Service:  m,
+		SpecFile: "testdata/cephfs.yaml",
+	})
+	r.NoError(err)
+}
+
 func TestDiffMultidoc(t *testing.T) {
 	r := require.New(t)
 
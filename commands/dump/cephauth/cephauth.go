@@ -0,0 +1,44 @@
+package cephauth
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephAuthConfig struct {
+	Printer printer.Printer
+	Service service.Service
+
+	// ShowSecrets includes entity keys into the output
+	ShowSecrets bool
+}
+
+func DumpCephAuth(ctx context.Context, doc DumpCephAuthConfig) error {
+	type outputSpec struct {
+		Kind string          `yaml:"kind"`
+		Spec models.CephAuth `yaml:"spec"`
+	}
+
+	auth, err := doc.Service.DumpAuth(ctx, doc.ShowSecrets)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephAuth",
+		Spec: auth,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
@@ -0,0 +1,71 @@
+package cephauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephAuth(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpAuth", false).Return(models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+			},
+		},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephAuth\nspec:\n    client.rbd-prod:\n        caps:\n            mon: profile rbd\n            osd: profile rbd pool=rbd\n",
+	}).Return().Once()
+
+	err := DumpCephAuth(context.Background(), DumpCephAuthConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
+
+func TestDumpCephAuthShowSecrets(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpAuth", true).Return(models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+			},
+			Key: "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+		},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephAuth\nspec:\n    client.rbd-prod:\n        caps:\n            mon: profile rbd\n        key: AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==\n",
+	}).Return().Once()
+
+	err := DumpCephAuth(context.Background(), DumpCephAuthConfig{
+		Printer:     p,
+		Service:     m,
+		ShowSecrets: true,
+	})
+	r.NoError(err)
+}
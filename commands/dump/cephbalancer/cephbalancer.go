@@ -0,0 +1,41 @@
+package cephbalancer
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephBalancerConfig struct {
+	Printer printer.Printer
+	Service service.Service
+}
+
+func DumpCephBalancer(ctx context.Context, doc DumpCephBalancerConfig) error {
+	type outputSpec struct {
+		Kind string              `yaml:"kind"`
+		Spec models.CephBalancer `yaml:"spec"`
+	}
+
+	balancer, err := doc.Service.DumpBalancer(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephBalancer",
+		Spec: balancer,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
@@ -0,0 +1,38 @@
+package cephbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephBalancer(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpBalancer").Return(models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 5,
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephBalancer\nspec:\n    mode: upmap\n    active: true\n    upmap_max_deviation: 5\n",
+	}).Return().Once()
+
+	err := DumpCephBalancer(context.Background(), DumpCephBalancerConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
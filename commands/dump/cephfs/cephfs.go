@@ -0,0 +1,41 @@
+package cephfs
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephFSConfig struct {
+	Printer printer.Printer
+	Service service.Service
+}
+
+func DumpCephFS(ctx context.Context, doc DumpCephFSConfig) error {
+	type outputSpec struct {
+		Kind string        `yaml:"kind"`
+		Spec models.CephFS `yaml:"spec"`
+	}
+
+	fs, err := doc.Service.DumpFS(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephFS",
+		Spec: fs,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
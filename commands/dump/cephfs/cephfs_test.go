@@ -0,0 +1,43 @@
+package cephfs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/teran/go-ptr"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephFS(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpFS").Return(models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(1),
+			AllowStandbyReplay: ptr.Bool(false),
+			SessionTimeout:     ptr.Uint32(60),
+			MaxFileSize:        ptr.Uint64(1099511627776),
+			Joinable:           ptr.Bool(true),
+		},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephFS\nspec:\n    shared-data:\n        max_mds: 1\n        allow_standby_replay: false\n        session_timeout: 60\n        max_file_size: 1099511627776\n        joinable: true\n",
+	}).Return().Once()
+
+	err := DumpCephFS(context.Background(), DumpCephFSConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
@@ -0,0 +1,41 @@
+package cephhealthmutes
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephHealthMutesConfig struct {
+	Printer printer.Printer
+	Service service.Service
+}
+
+func DumpCephHealthMutes(ctx context.Context, doc DumpCephHealthMutesConfig) error {
+	type outputSpec struct {
+		Kind string                 `yaml:"kind"`
+		Spec models.CephHealthMutes `yaml:"spec"`
+	}
+
+	mutes, err := doc.Service.DumpHealthMutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephHealthMutes",
+		Spec: mutes,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
@@ -0,0 +1,41 @@
+package cephhealthmutes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephHealthMutes(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpHealthMutes").Return(models.CephHealthMutes{
+		"OSDMAP_FLAGS": {
+			TTL:    90 * time.Minute,
+			Sticky: true,
+		},
+		"POOL_NO_REDUNDANCY": {},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephHealthMutes\nspec:\n    OSDMAP_FLAGS:\n        ttl: 1h30m0s\n        sticky: true\n    POOL_NO_REDUNDANCY: {}\n",
+	}).Return().Once()
+
+	err := DumpCephHealthMutes(context.Background(), DumpCephHealthMutesConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
@@ -0,0 +1,41 @@
+package cephmgrmodules
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephMgrModulesConfig struct {
+	Printer printer.Printer
+	Service service.Service
+}
+
+func DumpCephMgrModules(ctx context.Context, doc DumpCephMgrModulesConfig) error {
+	type outputSpec struct {
+		Kind string                `yaml:"kind"`
+		Spec models.CephMgrModules `yaml:"spec"`
+	}
+
+	modules, err := doc.Service.DumpMgrModules(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephMgrModules",
+		Spec: modules,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
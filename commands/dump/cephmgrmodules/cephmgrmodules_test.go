@@ -0,0 +1,37 @@
+package cephmgrmodules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephMgrModules(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpMgrModules").Return(models.CephMgrModules{
+		Enabled:  []string{"iostat", "prometheus"},
+		Disabled: []string{"dashboard"},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephMgrModules\nspec:\n    enabled:\n        - iostat\n        - prometheus\n    disabled:\n        - dashboard\n",
+	}).Return().Once()
+
+	err := DumpCephMgrModules(context.Background(), DumpCephMgrModulesConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
@@ -0,0 +1,41 @@
+package cephosdflags
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type DumpCephOSDFlagsConfig struct {
+	Printer printer.Printer
+	Service service.Service
+}
+
+func DumpCephOSDFlags(ctx context.Context, doc DumpCephOSDFlagsConfig) error {
+	type outputSpec struct {
+		Kind string              `yaml:"kind"`
+		Spec models.CephOSDFlags `yaml:"spec"`
+	}
+
+	flags, err := doc.Service.DumpOSDFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec := outputSpec{
+		Kind: "CephOSDFlags",
+		Spec: flags,
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	doc.Printer.Println(string(data))
+	return nil
+}
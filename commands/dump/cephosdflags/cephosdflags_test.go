@@ -0,0 +1,41 @@
+package cephosdflags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestDumpCephOSDFlags(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("DumpOSDFlags").Return(models.CephOSDFlags{
+		Cluster: []string{"noout", "noscrub"},
+		OSDs:    map[string][]string{},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noin"},
+		},
+		DeviceClasses: map[string][]string{},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephOSDFlags\nspec:\n    cluster:\n        - noout\n        - noscrub\n    crush_nodes:\n        nuc01:\n            - noin\n",
+	}).Return().Once()
+
+	err := DumpCephOSDFlags(context.Background(), DumpCephOSDFlagsConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
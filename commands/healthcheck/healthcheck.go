@@ -18,17 +18,39 @@ func Healthcheck(ctx context.Context, hc HealthcheckConfig) error {
 	indicators, err := hc.Service.CheckClusterHealth(ctx, []clusterHealth.ClusterHealthCheck{
 		clusterHealth.ClusterStatus,
 		clusterHealth.Quorum,
+		clusterHealth.MonsDown,
 		clusterHealth.OSDsDown,
 		clusterHealth.OSDsOut,
+		clusterHealth.OSDsLaggy,
 		clusterHealth.MutesAmount,
 		clusterHealth.DownPGs,
 		clusterHealth.UncleanPGs,
 		clusterHealth.InactivePGs,
+		clusterHealth.DegradedObjects,
+		clusterHealth.MisplacedObjects,
 		clusterHealth.AllowCrimson,
 		clusterHealth.OSDsMetadataSize,
 		clusterHealth.OSDsNumDaemonVersions,
 		clusterHealth.IPCollision,
+		clusterHealth.OSDsWithoutClusterAddress,
+		clusterHealth.OSDsSharedNetwork,
+		clusterHealth.OSDsHeartbeatAddress,
 		clusterHealth.DeviceHealth,
+		clusterHealth.Maintenance,
+		clusterHealth.Balancer,
+		clusterHealth.PoolRedundancy,
+		clusterHealth.FailureDomainCapacity,
+		clusterHealth.OSDsUtilizationSpread,
+		clusterHealth.OSDsFullness,
+		clusterHealth.DaemonVersions,
+		clusterHealth.ReleaseFlags,
+		clusterHealth.CRUSHTunables,
+		clusterHealth.HostSwap,
+		clusterHealth.HostArchitectures,
+		clusterHealth.HostKernelVersions,
+		clusterHealth.HostDistroVersions,
+		clusterHealth.OSDsNUMAUnknown,
+		clusterHealth.ClientIO,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,56 @@
+package end
+
+import (
+	"context"
+	"time"
+
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type EndConfig struct {
+	Printer printer.Printer
+	Service service.Service
+
+	// Wait for PGs to become active+clean after the flags are restored
+	Wait bool
+	// WaitTimeout is the maximum amount of time to wait for PGs
+	WaitTimeout time.Duration
+	// PollInterval is the interval to check PG states at
+	PollInterval time.Duration
+}
+
+func End(ctx context.Context, ec EndConfig) error {
+	lease, err := ec.Service.EndMaintenance(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range []map[string][]string{lease.Flags.OSDs, lease.Flags.CRUSHNodes} {
+		for name, flags := range group {
+			for _, flag := range flags {
+				ec.Printer.Red("- %s %s", name, flag)
+			}
+		}
+	}
+
+	for _, flag := range lease.Flags.Cluster {
+		ec.Printer.Red("- %s %s", "cluster", flag)
+	}
+
+	ec.Printer.Printf("maintenance of %s ended\n", lease.Who)
+
+	if !ec.Wait {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ec.WaitTimeout)
+	defer cancel()
+
+	if err := ec.Service.WaitForCleanPGs(ctx, ec.PollInterval); err != nil {
+		return err
+	}
+
+	ec.Printer.Green("all PGs are active+clean")
+	return nil
+}
@@ -0,0 +1,70 @@
+package end
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestEnd(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	call1 := m.On("EndMaintenance").Return(models.MaintenanceLease{
+		Who: "osd.3",
+		Flags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			OSDs: map[string][]string{
+				"osd.3": {"noout"},
+			},
+		},
+	}, nil).Once()
+	m.On("WaitForCleanPGs", 10*time.Second).Return(nil).NotBefore(call1).Once()
+
+	call2 := p.On("Red", "- %s %s", []any{"osd.3", "noout"}).Return().Once()
+	call3 := p.On("Red", "- %s %s", []any{"cluster", "norebalance"}).Return().NotBefore(call2).Once()
+	call4 := p.On("Printf", "maintenance of %s ended\n", []any{"osd.3"}).Return().NotBefore(call3).Once()
+	p.On("Green", "all PGs are active+clean", []any(nil)).Return().NotBefore(call4).Once()
+
+	err := End(context.Background(), EndConfig{
+		Printer:      p,
+		Service:      m,
+		Wait:         true,
+		WaitTimeout:  time.Minute,
+		PollInterval: 10 * time.Second,
+	})
+	r.NoError(err)
+}
+
+func TestEndNoWait(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("EndMaintenance").Return(models.MaintenanceLease{
+		Who: "nuc01",
+	}, nil).Once()
+
+	p.On("Printf", "maintenance of %s ended\n", []any{"nuc01"}).Return().Once()
+
+	err := End(context.Background(), EndConfig{
+		Printer: p,
+		Service: m,
+	})
+	r.NoError(err)
+}
@@ -0,0 +1,82 @@
+package start
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+	clusterHealth "github.com/runityru/cephctl/service/cluster_health"
+)
+
+// preconditions are the checks which must be good to start maintenance
+var preconditions = []clusterHealth.ClusterHealthCheck{
+	clusterHealth.Quorum,
+	clusterHealth.OSDsDown,
+	clusterHealth.DownPGs,
+	clusterHealth.InactivePGs,
+	clusterHealth.UncleanPGs,
+}
+
+type StartConfig struct {
+	Printer printer.Printer
+	Service service.Service
+
+	// Host is the CRUSH host to start maintenance of
+	Host string
+	// OSD is the OSD ID to start maintenance of
+	OSD string
+	// TTL is the maintenance lease duration, maintenance is reported
+	// by healthcheck as forgotten when it's expired
+	TTL time.Duration
+	// Force skips preconditions
+	Force bool
+}
+
+func Start(ctx context.Context, sc StartConfig) error {
+	var who string
+	switch {
+	case sc.Host != "" && sc.OSD != "":
+		return errors.New("either host or OSD must be specified, not both")
+	case sc.Host != "":
+		who = sc.Host
+	case sc.OSD != "":
+		id, err := strconv.ParseUint(sc.OSD, 10, 16)
+		if err != nil {
+			return errors.Wrapf(err, "invalid OSD ID `%s`", sc.OSD)
+		}
+		who = "osd." + strconv.FormatUint(id, 10)
+	default:
+		return errors.New("host or OSD must be specified")
+	}
+
+	checks := preconditions
+	if sc.Force {
+		log.Warn("preconditions are skipped")
+		checks = nil
+	}
+
+	lease, err := sc.Service.StartMaintenance(ctx, who, sc.TTL, checks)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range []map[string][]string{lease.Flags.OSDs, lease.Flags.CRUSHNodes} {
+		for name, flags := range group {
+			for _, flag := range flags {
+				sc.Printer.Green("+ %s %s", name, flag)
+			}
+		}
+	}
+
+	for _, flag := range lease.Flags.Cluster {
+		sc.Printer.Green("+ %s %s", "cluster", flag)
+	}
+
+	sc.Printer.Printf("maintenance of %s started, lease expires at %s\n", lease.Who, lease.ExpiresAt.UTC().Format(time.RFC3339))
+	return nil
+}
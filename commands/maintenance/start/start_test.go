@@ -0,0 +1,114 @@
+package start
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestStartHost(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("StartMaintenance", "nuc01", 4*time.Hour).Return(models.MaintenanceLease{
+		Who: "nuc01",
+		Flags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			CRUSHNodes: map[string][]string{
+				"nuc01": {"noout"},
+			},
+		},
+		ExpiresAt: time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC),
+	}, nil).Once()
+
+	call1 := p.On("Green", "+ %s %s", []any{"nuc01", "noout"}).Return().Once()
+	call2 := p.On("Green", "+ %s %s", []any{"cluster", "norebalance"}).Return().NotBefore(call1).Once()
+	p.On("Printf", "maintenance of %s started, lease expires at %s\n", []any{"nuc01", "2024-05-01T14:00:00Z"}).Return().NotBefore(call2).Once()
+
+	err := Start(context.Background(), StartConfig{
+		Printer: p,
+		Service: m,
+		Host:    "nuc01",
+		TTL:     4 * time.Hour,
+	})
+	r.NoError(err)
+}
+
+func TestStartOSD(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("StartMaintenance", "osd.3", time.Hour).Return(models.MaintenanceLease{
+		Who:       "osd.3",
+		ExpiresAt: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC),
+	}, nil).Once()
+
+	p.On("Printf", "maintenance of %s started, lease expires at %s\n", []any{"osd.3", "2024-05-01T11:00:00Z"}).Return().Once()
+
+	err := Start(context.Background(), StartConfig{
+		Printer: p,
+		Service: m,
+		OSD:     "3",
+		TTL:     time.Hour,
+		Force:   true,
+	})
+	r.NoError(err)
+}
+
+func TestStartInvalidTarget(t *testing.T) {
+	type testCase struct {
+		name     string
+		host     string
+		osd      string
+		expError string
+	}
+
+	tcs := []testCase{
+		{
+			name:     "no target",
+			expError: "host or OSD must be specified",
+		},
+		{
+			name:     "both targets",
+			host:     "nuc01",
+			osd:      "3",
+			expError: "either host or OSD must be specified, not both",
+		},
+		{
+			name:     "invalid OSD ID",
+			osd:      "osd.3",
+			expError: "invalid OSD ID `osd.3`: strconv.ParseUint: parsing \"osd.3\": invalid syntax",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			err := Start(context.Background(), StartConfig{
+				Printer: printer.NewMock(),
+				Service: service.NewMock(),
+				Host:    tc.host,
+				OSD:     tc.osd,
+			})
+			r.Error(err)
+			r.Equal(tc.expError, err.Error())
+		})
+	}
+}
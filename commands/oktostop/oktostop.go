@@ -0,0 +1,82 @@
+package oktostop
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+	clusterHealth "github.com/runityru/cephctl/service/cluster_health"
+)
+
+// ErrNoGo is returned when the host or OSD could not be stopped
+var ErrNoGo = errors.New("not ok to stop")
+
+// checks are combined with Ceph's own ok-to-stop answer
+var checks = []clusterHealth.ClusterHealthCheck{
+	clusterHealth.Quorum,
+	clusterHealth.DownPGs,
+	clusterHealth.InactivePGs,
+	clusterHealth.UncleanPGs,
+}
+
+type OkToStopConfig struct {
+	Printer printer.Printer
+	Service service.Service
+
+	// Host is the host to check OSDs of
+	Host string
+	// OSD is the OSD ID to check
+	OSD string
+	// Destroy requires OSDs to be safe to destroy, i.e. for disk replacement
+	Destroy bool
+}
+
+func OkToStop(ctx context.Context, oc OkToStopConfig) error {
+	var who string
+	switch {
+	case oc.Host != "" && oc.OSD != "":
+		return errors.New("either host or OSD must be specified, not both")
+	case oc.Host != "":
+		who = oc.Host
+	case oc.OSD != "":
+		id, err := strconv.ParseUint(oc.OSD, 10, 16)
+		if err != nil {
+			return errors.Wrapf(err, "invalid OSD ID `%s`", oc.OSD)
+		}
+		who = "osd." + strconv.FormatUint(id, 10)
+	default:
+		return errors.New("host or OSD must be specified")
+	}
+
+	verdict, err := oc.Service.CheckOkToStop(ctx, who, oc.Destroy, checks)
+	if err != nil {
+		return err
+	}
+
+	osds := []string{}
+	for _, id := range verdict.OSDs {
+		osds = append(osds, "osd."+strconv.FormatUint(uint64(id), 10))
+	}
+
+	if oc.Destroy {
+		oc.Printer.Printf("%s: %s, safe to destroy: %t\n", verdict.Who, strings.Join(osds, ", "), verdict.SafeToDestroy)
+	} else {
+		oc.Printer.Printf("%s: %s\n", verdict.Who, strings.Join(osds, ", "))
+	}
+
+	if verdict.Go {
+		oc.Printer.Green("GO")
+		return nil
+	}
+
+	oc.Printer.Red("NO-GO")
+	for _, reason := range verdict.Reasons {
+		oc.Printer.Printf("  * %s\n", reason)
+	}
+
+	return ErrNoGo
+}
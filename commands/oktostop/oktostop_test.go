@@ -0,0 +1,84 @@
+package oktostop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestOkToStopGo(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("CheckOkToStop", "nuc01", false).Return(models.OkToStopVerdict{
+		Who:     "nuc01",
+		OSDs:    []uint16{0, 1},
+		Go:      true,
+		Reasons: []string{},
+	}, nil).Once()
+
+	call1 := p.On("Printf", "%s: %s\n", []any{"nuc01", "osd.0, osd.1"}).Return().Once()
+	p.On("Green", "GO", []any(nil)).Return().NotBefore(call1).Once()
+
+	err := OkToStop(context.Background(), OkToStopConfig{
+		Printer: p,
+		Service: m,
+		Host:    "nuc01",
+	})
+	r.NoError(err)
+}
+
+func TestOkToStopNoGo(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("CheckOkToStop", "osd.3", true).Return(models.OkToStopVerdict{
+		Who:  "osd.3",
+		OSDs: []uint16{3},
+		Go:   false,
+		Reasons: []string{
+			"5 PG(s) are or would become inactive",
+			"QUORUM = 2 of 3",
+		},
+	}, nil).Once()
+
+	call1 := p.On("Printf", "%s: %s, safe to destroy: %t\n", []any{"osd.3", "osd.3", false}).Return().Once()
+	call2 := p.On("Red", "NO-GO", []any(nil)).Return().NotBefore(call1).Once()
+	call3 := p.On("Printf", "  * %s\n", []any{"5 PG(s) are or would become inactive"}).Return().NotBefore(call2).Once()
+	p.On("Printf", "  * %s\n", []any{"QUORUM = 2 of 3"}).Return().NotBefore(call3).Once()
+
+	err := OkToStop(context.Background(), OkToStopConfig{
+		Printer: p,
+		Service: m,
+		OSD:     "3",
+		Destroy: true,
+	})
+	r.Error(err)
+	r.ErrorIs(err, ErrNoGo)
+}
+
+func TestOkToStopNoTarget(t *testing.T) {
+	r := require.New(t)
+
+	err := OkToStop(context.Background(), OkToStopConfig{
+		Printer: printer.NewMock(),
+		Service: service.NewMock(),
+	})
+	r.Error(err)
+	r.Equal("host or OSD must be specified", err.Error())
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+type RecommendMemoryConfig struct {
+	Printer printer.Printer
+	Service service.Service
+
+	// ReservedBytes is the amount of memory left for OS and other daemons
+	ReservedBytes uint64
+	// Headroom is the ratio of memory OSDs could exceed the target by
+	Headroom float64
+}
+
+func RecommendMemory(ctx context.Context, rc RecommendMemoryConfig) error {
+	recs, err := rc.Service.RecommendOSDMemoryTarget(ctx, rc.ReservedBytes, rc.Headroom)
+	if err != nil {
+		return err
+	}
+
+	spec := &yaml.Node{Kind: yaml.MappingNode}
+	for _, rec := range recs {
+		if rec.Insufficient {
+			log.Warnf("host %s has not enough memory for %d OSD(s), minimal allowed osd_memory_target is used", rec.Hostname, rec.NumOSDs)
+		}
+
+		spec.Content = append(spec.Content,
+			&yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Value: "osd/host:" + rec.Hostname,
+				HeadComment: fmt.Sprintf(
+					"%s: %s of memory, %d OSD(s) (%d rotational)",
+					rec.Hostname, formatBytes(rec.MemoryTotalBytes), rec.NumOSDs, rec.NumRotationalOSDs,
+				),
+			},
+			&yaml.Node{
+				Kind: yaml.MappingNode,
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Value: "osd_memory_target"},
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: strconv.FormatUint(rec.OSDMemoryTargetBytes, 10)},
+				},
+			},
+		)
+	}
+
+	data, err := yaml.Marshal(&yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "kind"},
+			{Kind: yaml.ScalarNode, Value: "CephConfig"},
+			{Kind: yaml.ScalarNode, Value: "spec"},
+			spec,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	rc.Printer.Println(string(data))
+	return nil
+}
+
+func formatBytes(v uint64) string {
+	return strconv.FormatFloat(float64(v)/(1<<30), 'f', 1, 64) + " GiB"
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+	"github.com/runityru/cephctl/printer"
+	"github.com/runityru/cephctl/service"
+)
+
+func TestRecommendMemory(t *testing.T) {
+	r := require.New(t)
+
+	m := service.NewMock()
+	defer m.AssertExpectations(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	m.On("RecommendOSDMemoryTarget", uint64(4<<30), 0.2).Return([]models.OSDMemoryRecommendation{
+		{
+			Hostname:             "host1",
+			NumOSDs:              6,
+			MemoryTotalBytes:     8 << 30,
+			OSDMemoryTargetBytes: 896 << 20,
+			Insufficient:         true,
+		},
+		{
+			Hostname:             "host2",
+			NumOSDs:              4,
+			NumRotationalOSDs:    2,
+			MemoryTotalBytes:     64 << 30,
+			OSDMemoryTargetBytes: 12288 << 20,
+		},
+	}, nil).Once()
+
+	p.On("Println", []any{
+		"kind: CephConfig\n" +
+			"spec:\n" +
+			"    # host1: 8.0 GiB of memory, 6 OSD(s) (0 rotational)\n" +
+			"    osd/host:host1:\n" +
+			"        osd_memory_target: \"939524096\"\n" +
+			"    # host2: 64.0 GiB of memory, 4 OSD(s) (2 rotational)\n" +
+			"    osd/host:host2:\n" +
+			"        osd_memory_target: \"12884901888\"\n",
+	}).Return().Once()
+
+	err := RecommendMemory(context.Background(), RecommendMemoryConfig{
+		Printer:       p,
+		Service:       m,
+		ReservedBytes: 4 << 30,
+		Headroom:      0.2,
+	})
+	r.NoError(err)
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+type RenderConfig struct {
+	Printer     printer.Printer
+	SpecFile    string
+	SpecOptions []spec.Option
+}
+
+func Render(ctx context.Context, rc RenderConfig) error {
+	type outputSpec struct {
+		Kind string `yaml:"kind"`
+		Spec any    `yaml:"spec"`
+	}
+
+	descs, err := spec.NewFromDescription(rc.SpecFile, rc.SpecOptions...)
+	if err != nil {
+		return err
+	}
+
+	docs := []string{}
+	for _, desc := range descs {
+		var v any
+		if err := yaml.Unmarshal(desc.Spec, &v); err != nil {
+			return errors.Wrap(err, "error decoding spec")
+		}
+
+		data, err := yaml.Marshal(outputSpec{
+			Kind: desc.Kind,
+			Spec: v,
+		})
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, "---\n"+string(data))
+	}
+
+	rc.Printer.Println(strings.Join(docs, ""))
+	return nil
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+func TestRender(t *testing.T) {
+	r := require.New(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	p.On("Println", []any{
+		"---\nkind: CephConfig\nspec:\n    osd:\n        osd_max_backfills: \"4\"\n" +
+			"---\nkind: CephOSDConfig\nspec:\n    full_ratio: 0.95\n",
+	}).Return().Once()
+
+	err := Render(context.Background(), RenderConfig{
+		Printer:  p,
+		SpecFile: "testdata/cluster.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestRenderWithValues(t *testing.T) {
+	r := require.New(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	p.On("Println", []any{
+		"---\nkind: CephConfig\nspec:\n    global:\n        rgw_dns_name: s3.example.com\n",
+	}).Return().Once()
+
+	err := Render(context.Background(), RenderConfig{
+		Printer:  p,
+		SpecFile: "testdata/template.yaml",
+		SpecOptions: []spec.Option{
+			spec.WithValues(map[string]any{"rgw_dns_name": "s3.example.com"}),
+		},
+	})
+	r.NoError(err)
+}
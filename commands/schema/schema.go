@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+type SchemaConfig struct {
+	Printer printer.Printer
+	Kind    string
+}
+
+func Schema(ctx context.Context, sc SchemaConfig) error {
+	s, err := spec.Schema(sc.Kind)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error marshaling schema")
+	}
+
+	sc.Printer.Println(string(data))
+	return nil
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+func TestSchemaCephOSDConfig(t *testing.T) {
+	r := require.New(t)
+
+	expected, err := os.ReadFile("testdata/cephosdconfig.json")
+	r.NoError(err)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	p.On("Println", mock.Anything).Run(func(args mock.Arguments) {
+		r.JSONEq(string(expected), args.Get(0).([]any)[0].(string))
+	}).Return().Once()
+
+	err = Schema(context.Background(), SchemaConfig{
+		Printer: p,
+		Kind:    "cephosdconfig",
+	})
+	r.NoError(err)
+}
+
+func TestSchemaUnknownKind(t *testing.T) {
+	r := require.New(t)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	err := Schema(context.Background(), SchemaConfig{
+		Printer: p,
+		Kind:    "CephUnknown",
+	})
+	r.ErrorIs(err, spec.ErrUnknownKind)
+}
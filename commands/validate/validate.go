@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/ceph/config/options"
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+var ErrValidationFailed = errors.New("specification validation failed")
+
+type ValidateConfig struct {
+	Printer      printer.Printer
+	OptionSchema *options.Schema
+	SpecFile     string
+	SpecOptions  []spec.Option
+}
+
+func Validate(ctx context.Context, vc ValidateConfig) error {
+	descs, err := spec.NewFromDescription(vc.SpecFile, vc.SpecOptions...)
+	if err != nil {
+		return err
+	}
+
+	issues, err := vc.OptionSchema.Validate(descs)
+	if err != nil {
+		return err
+	}
+
+	numErrors := 0
+	for _, issue := range issues {
+		switch issue.Severity {
+		case options.SeverityError:
+			numErrors++
+			vc.Printer.Red("%s", issue)
+		default:
+			vc.Printer.Yellow("%s", issue)
+		}
+	}
+
+	if numErrors > 0 {
+		return errors.Wrapf(ErrValidationFailed, "%d error(s) found", numErrors)
+	}
+
+	vc.Printer.Green("%s: specification is valid against %s option schema", vc.SpecFile, vc.OptionSchema.Release)
+	return nil
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/ceph/config/options"
+	"github.com/runityru/cephctl/ceph/config/spec"
+	"github.com/runityru/cephctl/printer"
+)
+
+func TestValidate(t *testing.T) {
+	r := require.New(t)
+
+	s, err := options.Load("reef")
+	r.NoError(err)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	p.On("Green", "%s: specification is valid against %s option schema", []any{"testdata/valid.yaml", "reef"}).Return().Once()
+
+	err = Validate(context.Background(), ValidateConfig{
+		Printer:      p,
+		OptionSchema: s,
+		SpecFile:     "testdata/valid.yaml",
+	})
+	r.NoError(err)
+}
+
+func TestValidateFailed(t *testing.T) {
+	r := require.New(t)
+
+	s, err := options.Load("reef")
+	r.NoError(err)
+
+	p := printer.NewMock()
+	defer p.AssertExpectations(t)
+
+	p.On("Yellow", "%s", []any{options.Issue{
+		Location: spec.Location{Filename: "testdata/invalid.yaml", Line: 5, Column: 5},
+		Severity: options.SeverityWarning,
+		Message:  "option `some_unknown_option_name` is not known for reef release",
+	}}).Return().Once()
+	p.On("Red", "%s", []any{options.Issue{
+		Location: spec.Location{Filename: "testdata/invalid.yaml", Line: 7, Column: 5},
+		Severity: options.SeverityError,
+		Message:  "unknown option `osd_max_backfils`, did you mean `osd_max_backfills`?",
+	}}).Return().Once()
+	p.On("Red", "%s", []any{options.Issue{
+		Location: spec.Location{Filename: "testdata/invalid.yaml", Line: 8, Column: 5},
+		Severity: options.SeverityError,
+		Message:  "invalid value for `osd_scrub_begin_hour` (int): `25` is greater than maximal allowed value 23",
+	}}).Return().Once()
+
+	err = Validate(context.Background(), ValidateConfig{
+		Printer:      p,
+		OptionSchema: s,
+		SpecFile:     "testdata/invalid.yaml",
+	})
+	r.ErrorIs(err, ErrValidationFailed)
+}
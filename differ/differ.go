@@ -2,8 +2,12 @@ package differ
 
 import (
 	"context"
+	"maps"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	diff "github.com/r3labs/diff/v3"
@@ -13,13 +17,33 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-const flattenMapSeparator = ":::"
+const (
+	flattenMapSeparator = ":::"
 
-var ErrUnexpectedOperationType = errors.New("unexpected operation type")
+	// muteExpirationPrecision is the error of mute expiration time reported
+	// by Ceph the remaining time of the mute is compared with TTL within
+	muteExpirationPrecision = time.Minute
+)
+
+var (
+	ErrUnexpectedOperationType = errors.New("unexpected operation type")
+	ErrUnknownMgrModule        = errors.New("unknown manager module")
+	ErrMgrModuleConflict       = errors.New("manager module is both enabled and disabled")
+	ErrUnknownAuthEntity       = errors.New("unknown auth entity")
+	ErrAuthEntityConflict      = errors.New("auth entity matches several patterns")
+	ErrEmptyAuthCaps           = errors.New("auth entity caps are empty")
+	ErrUnknownFileSystem       = errors.New("unknown filesystem")
+)
 
 type Differ interface {
 	DiffCephConfig(ctx context.Context, from, to models.CephConfig) ([]models.CephConfigDifference, error)
 	DiffCephOSDConfig(ctx context.Context, from, to models.CephOSDConfig) ([]models.CephOSDConfigDifference, error)
+	DiffCephOSDFlags(ctx context.Context, from, to models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error)
+	DiffCephMgrModules(ctx context.Context, from models.MgrModules, to models.CephMgrModules) ([]models.CephMgrModulesDifference, error)
+	DiffCephBalancer(ctx context.Context, from, to models.CephBalancer) ([]models.CephBalancerDifference, error)
+	DiffCephHealthMutes(ctx context.Context, from []models.ClusterStatusMutedCheck, to models.CephHealthMutes) ([]models.CephHealthMutesDifference, error)
+	DiffCephAuth(ctx context.Context, from []models.AuthEntity, to models.CephAuth) ([]models.CephAuthDifference, error)
+	DiffCephFS(ctx context.Context, from []models.FileSystem, to models.CephFS) ([]models.CephFSDifference, error)
 }
 
 type differ struct{}
@@ -52,7 +76,7 @@ func (d *differ) DiffCephConfig(ctx context.Context, from, to models.CephConfig)
 			return nil, errors.Errorf("unexpected path received: no flattened parts found (%d received)", len(pathParts))
 		}
 
-		section := pathParts[0]
+		section, mask := models.ParseConfigTarget(pathParts[0])
 		if len(section) == 0 {
 			return nil, errors.Errorf("section name cannot be empty")
 		}
@@ -73,6 +97,7 @@ func (d *differ) DiffCephConfig(ctx context.Context, from, to models.CephConfig)
 			changes = append(changes, models.CephConfigDifference{
 				Kind:    models.CephConfigDifferenceKindAdd,
 				Section: section,
+				Mask:    mask,
 				Key:     key,
 				Value:   ptr.String(v),
 			})
@@ -93,6 +118,7 @@ func (d *differ) DiffCephConfig(ctx context.Context, from, to models.CephConfig)
 			changes = append(changes, models.CephConfigDifference{
 				Kind:     models.CephConfigDifferenceKindChange,
 				Section:  section,
+				Mask:     mask,
 				Key:      key,
 				OldValue: ptr.String(oldV),
 				Value:    ptr.String(v),
@@ -102,6 +128,7 @@ func (d *differ) DiffCephConfig(ctx context.Context, from, to models.CephConfig)
 			changes = append(changes, models.CephConfigDifference{
 				Kind:    models.CephConfigDifferenceKindRemove,
 				Section: section,
+				Mask:    mask,
 				Key:     key,
 			})
 
@@ -160,3 +187,375 @@ func (d *differ) DiffCephOSDConfig(ctx context.Context, from, to models.CephOSDC
 
 	return changes, nil
 }
+
+// DiffCephOSDFlags compares flag sets: flags missing in `from` are to be
+// set and flags missing in `to` are to be unset. Changes are ordered
+// cluster-wide first, then by OSD, CRUSH node and device class
+func (d *differ) DiffCephOSDFlags(ctx context.Context, from, to models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error) {
+	changes := diffFlags("", from.Cluster, to.Cluster)
+	for _, groups := range [][2]map[string][]string{
+		{from.OSDs, to.OSDs},
+		{from.CRUSHNodes, to.CRUSHNodes},
+		{from.DeviceClasses, to.DeviceClasses},
+	} {
+		changes = append(changes, diffFlagGroups(groups[0], groups[1])...)
+	}
+
+	log.WithFields(log.Fields{
+		"component": "differ",
+	}).Tracef("diff generated: %#v", changes)
+
+	return changes, nil
+}
+
+// DiffCephBalancer compares balancer configurations, changes are ordered
+// the way they should be applied: mode and deviation are set before the
+// balancer is switched on
+func (d *differ) DiffCephBalancer(ctx context.Context, from, to models.CephBalancer) ([]models.CephBalancerDifference, error) {
+	changes := []models.CephBalancerDifference{}
+	if from.Mode != to.Mode {
+		changes = append(changes, models.CephBalancerDifference{
+			Key:      "mode",
+			OldValue: from.Mode,
+			Value:    to.Mode,
+		})
+	}
+
+	if from.UpmapMaxDeviation != to.UpmapMaxDeviation {
+		changes = append(changes, models.CephBalancerDifference{
+			Key:      "upmap_max_deviation",
+			OldValue: strconv.FormatUint(uint64(from.UpmapMaxDeviation), 10),
+			Value:    strconv.FormatUint(uint64(to.UpmapMaxDeviation), 10),
+		})
+	}
+
+	if from.Active != to.Active {
+		changes = append(changes, models.CephBalancerDifference{
+			Key:      "active",
+			OldValue: strconv.FormatBool(from.Active),
+			Value:    strconv.FormatBool(to.Active),
+		})
+	}
+
+	return changes, nil
+}
+
+// DiffCephHealthMutes compares mutes set on the cluster with the intended
+// ones. Since Ceph reports expiration time only the mute is reported as
+// changed when it's permanent while TTL is set (or vice versa) or it
+// expires later than TTL from now, so the mute set earlier is not set again
+// and expires in time. Ceph removes expired mutes so the ones still in
+// specification are reported as missing and set again.
+func (d *differ) DiffCephHealthMutes(ctx context.Context, from []models.ClusterStatusMutedCheck, to models.CephHealthMutes) ([]models.CephHealthMutesDifference, error) {
+	now := time.Now()
+
+	current := map[string]models.ClusterStatusMutedCheck{}
+	codes := []string{}
+	for _, m := range from {
+		current[m.Code] = m
+		codes = append(codes, m.Code)
+	}
+	for code := range to {
+		codes = append(codes, code)
+	}
+
+	changes := []models.CephHealthMutesDifference{}
+	for _, code := range sortedUnique(codes) {
+		mute, intended := to[code]
+		set, ok := current[code]
+
+		switch {
+		case !ok:
+			changes = append(changes, models.CephHealthMutesDifference{
+				Kind: models.CephHealthMutesDifferenceKindMute,
+				Code: code,
+				Mute: mute,
+			})
+		case !intended:
+			changes = append(changes, models.CephHealthMutesDifference{
+				Kind:      models.CephHealthMutesDifferenceKindUnmute,
+				Code:      code,
+				ExpiresAt: set.ExpiresAt,
+			})
+		case set.Sticky != mute.Sticky, !muteTTLMatches(mute.TTL, set.ExpiresAt, now):
+			changes = append(changes, models.CephHealthMutesDifference{
+				Kind:      models.CephHealthMutesDifferenceKindChange,
+				Code:      code,
+				Mute:      mute,
+				ExpiresAt: set.ExpiresAt,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// muteTTLMatches reports whether the mute expiring at expiresAt (nil for
+// permanent one) could be set with the TTL, the mute set earlier has less
+// time left than TTL
+func muteTTLMatches(ttl time.Duration, expiresAt *time.Time, now time.Time) bool {
+	switch {
+	case ttl == 0:
+		return expiresAt == nil
+	case expiresAt == nil:
+		return false
+	}
+	return !expiresAt.After(now.Add(ttl + muteExpirationPrecision))
+}
+
+// DiffCephMgrModules compares manager modules state with the desired one:
+// modules are enabled or disabled only if they're listed in specification.
+// Always-on modules are always enabled so they're skipped with a warning
+// if listed as disabled.
+func (d *differ) DiffCephMgrModules(ctx context.Context, from models.MgrModules, to models.CephMgrModules) ([]models.CephMgrModulesDifference, error) {
+	changes := []models.CephMgrModulesDifference{}
+	for _, module := range sortedUnique(to.Enabled) {
+		if slices.Contains(to.Disabled, module) {
+			return nil, errors.Wrapf(ErrMgrModuleConflict, "`%s`", module)
+		}
+
+		switch {
+		case slices.Contains(from.AlwaysOn, module), slices.Contains(from.Enabled, module):
+		case slices.Contains(from.Disabled, module):
+			changes = append(changes, models.CephMgrModulesDifference{
+				Kind:   models.CephMgrModulesDifferenceKindEnable,
+				Module: module,
+			})
+		default:
+			return nil, errors.Wrapf(ErrUnknownMgrModule, "`%s`", module)
+		}
+	}
+
+	for _, module := range sortedUnique(to.Disabled) {
+		switch {
+		case slices.Contains(from.AlwaysOn, module):
+			log.Warnf("manager module `%s` is always on and could not be disabled", module)
+		case slices.Contains(from.Enabled, module):
+			changes = append(changes, models.CephMgrModulesDifference{
+				Kind:   models.CephMgrModulesDifferenceKindDisable,
+				Module: module,
+			})
+		case slices.Contains(from.Disabled, module):
+		default:
+			return nil, errors.Wrapf(ErrUnknownMgrModule, "`%s`", module)
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"component": "differ",
+	}).Tracef("diff generated: %#v", changes)
+
+	return changes, nil
+}
+
+// DiffCephAuth compares caps of the entities with the intended ones, the
+// entity listed by name takes precedence over the patterns matching it.
+// Entities are never created so entity listed by name must exist. Since all
+// the caps are replaced at once the caps must not be empty and the admin
+// and monitor entities are never matched by patterns to avoid locking out.
+func (d *differ) DiffCephAuth(ctx context.Context, from []models.AuthEntity, to models.CephAuth) ([]models.CephAuthDifference, error) {
+	existing := map[string]struct{}{}
+	for _, e := range from {
+		existing[e.Name] = struct{}{}
+	}
+
+	patterns := []string{}
+	for name, entity := range to {
+		if len(entity.Caps) == 0 {
+			return nil, errors.Wrapf(ErrEmptyAuthCaps, "`%s`", name)
+		}
+
+		if !isPattern(name) {
+			if _, ok := existing[name]; !ok {
+				return nil, errors.Wrapf(ErrUnknownAuthEntity, "`%s`", name)
+			}
+			continue
+		}
+
+		if _, err := path.Match(name, ""); err != nil {
+			return nil, errors.Wrapf(err, "error parsing pattern `%s`", name)
+		}
+		patterns = append(patterns, name)
+	}
+	slices.Sort(patterns)
+
+	changes := []models.CephAuthDifference{}
+	for _, e := range slices.SortedFunc(slices.Values(from), func(a, b models.AuthEntity) int {
+		return strings.Compare(a.Name, b.Name)
+	}) {
+		entity, ok := to[e.Name]
+		if !ok {
+			if isPrivilegedAuthEntity(e.Name) {
+				continue
+			}
+
+			matched := []string{}
+			for _, p := range patterns {
+				if m, _ := path.Match(p, e.Name); m {
+					matched = append(matched, p)
+				}
+			}
+
+			if len(matched) == 0 {
+				continue
+			}
+			if len(matched) > 1 {
+				return nil, errors.Wrapf(ErrAuthEntityConflict, "`%s` matches %s", e.Name, strings.Join(matched, ", "))
+			}
+			entity = to[matched[0]]
+		}
+
+		changes = append(changes, diffCaps(e.Name, e.Caps, entity.Caps)...)
+	}
+
+	return changes, nil
+}
+
+// DiffCephFS compares settings of the filesystems with the intended ones,
+// settings not listed in specification are skipped
+func (d *differ) DiffCephFS(ctx context.Context, from []models.FileSystem, to models.CephFS) ([]models.CephFSDifference, error) {
+	current := map[string]models.FileSystem{}
+	for _, fs := range from {
+		current[fs.Name] = fs
+	}
+
+	changes := []models.CephFSDifference{}
+	for _, name := range slices.Sorted(maps.Keys(to)) {
+		fs, ok := current[name]
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownFileSystem, "`%s`", name)
+		}
+
+		settings := to[name]
+		values := []struct {
+			key      string
+			oldValue string
+			value    *string
+		}{
+			{"max_mds", strconv.FormatUint(uint64(fs.MaxMDS), 10), formatPtr(settings.MaxMDS, func(v uint16) string { return strconv.FormatUint(uint64(v), 10) })},
+			{"allow_standby_replay", strconv.FormatBool(fs.AllowStandbyReplay), formatPtr(settings.AllowStandbyReplay, strconv.FormatBool)},
+			{"session_timeout", strconv.FormatUint(uint64(fs.SessionTimeout), 10), formatPtr(settings.SessionTimeout, func(v uint32) string { return strconv.FormatUint(uint64(v), 10) })},
+			{"max_file_size", strconv.FormatUint(fs.MaxFileSize, 10), formatPtr(settings.MaxFileSize, func(v uint64) string { return strconv.FormatUint(v, 10) })},
+			{"joinable", strconv.FormatBool(fs.Joinable), formatPtr(settings.Joinable, strconv.FormatBool)},
+		}
+
+		for _, v := range values {
+			if v.value == nil || *v.value == v.oldValue {
+				continue
+			}
+
+			changes = append(changes, models.CephFSDifference{
+				FileSystem: name,
+				Key:        v.key,
+				OldValue:   v.oldValue,
+				Value:      *v.value,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func diffFlagGroups(from, to map[string][]string) []models.CephOSDFlagsDifference {
+	names := []string{}
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	changes := []models.CephOSDFlagsDifference{}
+	for _, name := range names {
+		changes = append(changes, diffFlags(name, from[name], to[name])...)
+	}
+	return changes
+}
+
+func diffFlags(who string, from, to []string) []models.CephOSDFlagsDifference {
+	changes := []models.CephOSDFlagsDifference{}
+	for _, flag := range sortedUnique(to) {
+		if !slices.Contains(from, flag) {
+			changes = append(changes, models.CephOSDFlagsDifference{
+				Kind: models.CephOSDFlagsDifferenceKindSet,
+				Who:  who,
+				Flag: flag,
+			})
+		}
+	}
+
+	for _, flag := range sortedUnique(from) {
+		if !slices.Contains(to, flag) {
+			changes = append(changes, models.CephOSDFlagsDifference{
+				Kind: models.CephOSDFlagsDifferenceKindUnset,
+				Who:  who,
+				Flag: flag,
+			})
+		}
+	}
+	return changes
+}
+
+func sortedUnique(in []string) []string {
+	out := slices.Clone(in)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func diffCaps(entity string, from, to map[string]string) []models.CephAuthDifference {
+	daemons := slices.Collect(maps.Keys(from))
+	daemons = append(daemons, slices.Collect(maps.Keys(to))...)
+
+	changes := []models.CephAuthDifference{}
+	for _, daemon := range sortedUnique(daemons) {
+		oldCaps, wasSet := from[daemon]
+		caps, isSet := to[daemon]
+
+		switch {
+		case !wasSet:
+			changes = append(changes, models.CephAuthDifference{
+				Kind:   models.CephAuthDifferenceKindAdd,
+				Entity: entity,
+				Daemon: daemon,
+				Caps:   caps,
+			})
+		case !isSet:
+			changes = append(changes, models.CephAuthDifference{
+				Kind:    models.CephAuthDifferenceKindRemove,
+				Entity:  entity,
+				Daemon:  daemon,
+				OldCaps: oldCaps,
+			})
+		case oldCaps != caps:
+			changes = append(changes, models.CephAuthDifference{
+				Kind:    models.CephAuthDifferenceKindChange,
+				Entity:  entity,
+				Daemon:  daemon,
+				OldCaps: oldCaps,
+				Caps:    caps,
+			})
+		}
+	}
+	return changes
+}
+
+func isPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// isPrivilegedAuthEntity reports whether the entity is the admin or monitor
+// one which caps are managed only if it's listed by name
+func isPrivilegedAuthEntity(name string) bool {
+	return name == "client.admin" || strings.HasPrefix(name, "mon.")
+}
+
+// formatPtr formats the value if it's set
+func formatPtr[T any](v *T, format func(T) string) *string {
+	if v == nil {
+		return nil
+	}
+	return ptr.String(format(*v))
+}
@@ -3,6 +3,7 @@ package differ
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -61,6 +62,74 @@ func (s *differTestSuite) TestDiffCephConfig() {
 				},
 			},
 		},
+		{
+			name: "masked config",
+			from: models.CephConfig{
+				"osd": {
+					"osd_memory_target": "4294967296",
+				},
+				"osd/host:nuc01": {
+					"osd_memory_target": "8589934592",
+				},
+				"osd/class:hdd": {
+					"osd_recovery_sleep": "0.1",
+				},
+			},
+			to: models.CephConfig{
+				"osd": {
+					"osd_memory_target": "4294967296",
+				},
+				"osd/host:nuc01": {
+					"osd_memory_target": "6442450944",
+				},
+				"osd/class:ssd": {
+					"osd_recovery_sleep": "0",
+				},
+			},
+			expOut: []models.CephConfigDifference{
+				{
+					Kind:     models.CephConfigDifferenceKindChange,
+					Section:  "osd",
+					Mask:     "host:nuc01",
+					Key:      "osd_memory_target",
+					OldValue: ptr.String("8589934592"),
+					Value:    ptr.String("6442450944"),
+				},
+				{
+					Kind:    models.CephConfigDifferenceKindAdd,
+					Section: "osd",
+					Mask:    "class:ssd",
+					Key:     "osd_recovery_sleep",
+					Value:   ptr.String("0"),
+				},
+				{
+					Kind:    models.CephConfigDifferenceKindRemove,
+					Section: "osd",
+					Mask:    "class:hdd",
+					Key:     "osd_recovery_sleep",
+				},
+			},
+		},
+		{
+			name: "masked config round-trip",
+			from: models.CephConfig{
+				"osd": {
+					"osd_memory_target": "4294967296",
+				},
+				"osd/host:nuc01": {
+					"osd_memory_target": "8589934592",
+				},
+			},
+			to: models.CephConfig{
+				"osd": {
+					"osd_memory_target": "4294967296",
+				},
+				"osd/host:nuc01": {
+					"osd_memory_target": "8589934592",
+				},
+			},
+			expOut: []models.CephConfigDifference{},
+		},
 		{
 			name:   "empty map",
 			from:   models.CephConfig{},
@@ -179,6 +248,596 @@ func (s *differTestSuite) TestDiffCephOSDConfig() {
 	}
 }
 
+func (s *differTestSuite) TestDiffCephOSDFlags() {
+	type testCase struct {
+		name   string
+		from   models.CephOSDFlags
+		to     models.CephOSDFlags
+		expOut []models.CephOSDFlagsDifference
+	}
+
+	tcs := []testCase{
+		{
+			name: "forgotten maintenance flags",
+			from: models.CephOSDFlags{
+				Cluster: []string{"noout", "norebalance", "noscrub"},
+				OSDs: map[string][]string{
+					"osd.3": {"noout"},
+				},
+				CRUSHNodes: map[string][]string{
+					"nuc01": {"noout", "noin"},
+				},
+			},
+			to: models.CephOSDFlags{
+				Cluster: []string{"noscrub", "nodeep-scrub"},
+				CRUSHNodes: map[string][]string{
+					"nuc01": {"noin"},
+				},
+				DeviceClasses: map[string][]string{
+					"hdd": {"nodown"},
+				},
+			},
+			expOut: []models.CephOSDFlagsDifference{
+				{Kind: models.CephOSDFlagsDifferenceKindSet, Flag: "nodeep-scrub"},
+				{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "noout"},
+				{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "norebalance"},
+				{Kind: models.CephOSDFlagsDifferenceKindUnset, Who: "osd.3", Flag: "noout"},
+				{Kind: models.CephOSDFlagsDifferenceKindUnset, Who: "nuc01", Flag: "noout"},
+				{Kind: models.CephOSDFlagsDifferenceKindSet, Who: "hdd", Flag: "nodown"},
+			},
+		},
+		{
+			name: "nil and empty are the same",
+			from: models.CephOSDFlags{
+				Cluster:       []string{},
+				OSDs:          map[string][]string{},
+				CRUSHNodes:    map[string][]string{"nuc01": {}},
+				DeviceClasses: map[string][]string{},
+			},
+			to:     models.CephOSDFlags{},
+			expOut: []models.CephOSDFlagsDifference{},
+		},
+		{
+			name: "duplicate flags",
+			from: models.CephOSDFlags{},
+			to: models.CephOSDFlags{
+				Cluster: []string{"noout", "noout"},
+			},
+			expOut: []models.CephOSDFlagsDifference{
+				{Kind: models.CephOSDFlagsDifferenceKindSet, Flag: "noout"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephOSDFlags(s.ctx, tc.from, tc.to)
+			r.NoError(err)
+			r.Equal(tc.expOut, diff)
+		})
+	}
+}
+
+func (s *differTestSuite) TestDiffCephBalancer() {
+	type testCase struct {
+		name   string
+		from   models.CephBalancer
+		to     models.CephBalancer
+		expOut []models.CephBalancerDifference
+	}
+
+	tcs := []testCase{
+		{
+			name: "all fields changed",
+			from: models.CephBalancer{
+				Mode:              "crush-compat",
+				Active:            false,
+				UpmapMaxDeviation: 5,
+			},
+			to: models.CephBalancer{
+				Mode:              "upmap",
+				Active:            true,
+				UpmapMaxDeviation: 1,
+			},
+			expOut: []models.CephBalancerDifference{
+				{Key: "mode", OldValue: "crush-compat", Value: "upmap"},
+				{Key: "upmap_max_deviation", OldValue: "5", Value: "1"},
+				{Key: "active", OldValue: "false", Value: "true"},
+			},
+		},
+		{
+			name: "no changes",
+			from: models.CephBalancer{
+				Mode:              "upmap",
+				Active:            true,
+				UpmapMaxDeviation: 5,
+			},
+			to: models.CephBalancer{
+				Mode:              "upmap",
+				Active:            true,
+				UpmapMaxDeviation: 5,
+			},
+			expOut: []models.CephBalancerDifference{},
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephBalancer(s.ctx, tc.from, tc.to)
+			r.NoError(err)
+			r.Equal(tc.expOut, diff)
+		})
+	}
+}
+
+func (s *differTestSuite) TestDiffCephHealthMutes() {
+	expiresAt := time.Now().Add(time.Hour)
+
+	type testCase struct {
+		name   string
+		from   []models.ClusterStatusMutedCheck
+		to     models.CephHealthMutes
+		expOut []models.CephHealthMutesDifference
+	}
+
+	tcs := []testCase{
+		{
+			name: "mute",
+			from: []models.ClusterStatusMutedCheck{},
+			to: models.CephHealthMutes{
+				"OSDMAP_FLAGS": {
+					TTL:           4 * time.Hour,
+					Justification: "maintenance of nuc01",
+				},
+			},
+			expOut: []models.CephHealthMutesDifference{
+				{
+					Kind: models.CephHealthMutesDifferenceKindMute,
+					Code: "OSDMAP_FLAGS",
+					Mute: models.CephHealthMute{
+						TTL:           4 * time.Hour,
+						Justification: "maintenance of nuc01",
+					},
+				},
+			},
+		},
+		{
+			name: "unexpected mutes",
+			from: []models.ClusterStatusMutedCheck{
+				{Code: "POOL_NO_REDUNDANCY"},
+				{Code: "OSDMAP_FLAGS", ExpiresAt: &expiresAt},
+			},
+			to: models.CephHealthMutes{
+				"OSDMAP_FLAGS": {
+					TTL: 4 * time.Hour,
+				},
+			},
+			expOut: []models.CephHealthMutesDifference{
+				{
+					Kind: models.CephHealthMutesDifferenceKindUnmute,
+					Code: "POOL_NO_REDUNDANCY",
+				},
+			},
+		},
+		{
+			name: "sticky changed",
+			from: []models.ClusterStatusMutedCheck{
+				{Code: "OSD_NEARFULL", ExpiresAt: &expiresAt},
+			},
+			to: models.CephHealthMutes{
+				"OSD_NEARFULL": {
+					Sticky: true,
+				},
+			},
+			expOut: []models.CephHealthMutesDifference{
+				{
+					Kind:      models.CephHealthMutesDifferenceKindChange,
+					Code:      "OSD_NEARFULL",
+					Mute:      models.CephHealthMute{Sticky: true},
+					ExpiresAt: &expiresAt,
+				},
+			},
+		},
+		{
+			name: "TTL changed",
+			from: []models.ClusterStatusMutedCheck{
+				{Code: "OSD_NEARFULL", ExpiresAt: &expiresAt},
+				{Code: "OSDMAP_FLAGS"},
+				{Code: "POOL_NO_REDUNDANCY", ExpiresAt: &expiresAt},
+			},
+			to: models.CephHealthMutes{
+				"OSD_NEARFULL":       {TTL: 10 * time.Minute},
+				"OSDMAP_FLAGS":       {TTL: 4 * time.Hour},
+				"POOL_NO_REDUNDANCY": {},
+			},
+			expOut: []models.CephHealthMutesDifference{
+				{
+					Kind: models.CephHealthMutesDifferenceKindChange,
+					Code: "OSDMAP_FLAGS",
+					Mute: models.CephHealthMute{TTL: 4 * time.Hour},
+				},
+				{
+					Kind:      models.CephHealthMutesDifferenceKindChange,
+					Code:      "OSD_NEARFULL",
+					Mute:      models.CephHealthMute{TTL: 10 * time.Minute},
+					ExpiresAt: &expiresAt,
+				},
+				{
+					Kind:      models.CephHealthMutesDifferenceKindChange,
+					Code:      "POOL_NO_REDUNDANCY",
+					ExpiresAt: &expiresAt,
+				},
+			},
+		},
+		{
+			name: "no changes",
+			from: []models.ClusterStatusMutedCheck{
+				{Code: "OSD_NEARFULL", Sticky: true, ExpiresAt: &expiresAt},
+			},
+			to: models.CephHealthMutes{
+				"OSD_NEARFULL": {
+					TTL:    time.Hour,
+					Sticky: true,
+				},
+			},
+			expOut: []models.CephHealthMutesDifference{},
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephHealthMutes(s.ctx, tc.from, tc.to)
+			r.NoError(err)
+			r.Equal(tc.expOut, diff)
+		})
+	}
+}
+
+func (s *differTestSuite) TestDiffCephMgrModules() {
+	type testCase struct {
+		name     string
+		from     models.MgrModules
+		to       models.CephMgrModules
+		expOut   []models.CephMgrModulesDifference
+		expError string
+	}
+
+	from := models.MgrModules{
+		AlwaysOn: []string{"balancer", "pg_autoscaler", "telemetry"},
+		Enabled:  []string{"iostat", "restful"},
+		Disabled: []string{"dashboard", "prometheus"},
+	}
+
+	tcs := []testCase{
+		{
+			name: "enable and disable modules",
+			from: from,
+			to: models.CephMgrModules{
+				Enabled:  []string{"prometheus", "iostat", "balancer"},
+				Disabled: []string{"restful", "dashboard"},
+			},
+			expOut: []models.CephMgrModulesDifference{
+				{Kind: models.CephMgrModulesDifferenceKindEnable, Module: "prometheus"},
+				{Kind: models.CephMgrModulesDifferenceKindDisable, Module: "restful"},
+			},
+		},
+		{
+			name: "always-on module disabled",
+			from: from,
+			to: models.CephMgrModules{
+				Disabled: []string{"telemetry"},
+			},
+			expOut: []models.CephMgrModulesDifference{},
+		},
+		{
+			name: "unknown module",
+			from: from,
+			to: models.CephMgrModules{
+				Enabled: []string{"zabbix"},
+			},
+			expError: "`zabbix`: unknown manager module",
+		},
+		{
+			name: "conflict",
+			from: from,
+			to: models.CephMgrModules{
+				Enabled:  []string{"prometheus"},
+				Disabled: []string{"prometheus"},
+			},
+			expError: "`prometheus`: manager module is both enabled and disabled",
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephMgrModules(s.ctx, tc.from, tc.to)
+			if tc.expError != "" {
+				r.Error(err)
+				r.Equal(tc.expError, err.Error())
+			} else {
+				r.NoError(err)
+				r.Equal(tc.expOut, diff)
+			}
+		})
+	}
+}
+
+func (s *differTestSuite) TestDiffCephAuth() {
+	from := []models.AuthEntity{
+		{
+			Name: "client.rbd-prod",
+			Key:  "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+			Caps: map[string]string{
+				"mon": "profile rbd",
+				"osd": "profile rbd pool=rbd",
+				"mds": "allow",
+			},
+		},
+		{
+			Name: "client.rgw.nuc01",
+			Caps: map[string]string{
+				"mon": "allow rw",
+				"osd": "allow rwx",
+			},
+		},
+		{
+			Name: "client.rgw.nuc02",
+			Caps: map[string]string{
+				"mon": "allow rw",
+			},
+		},
+		{
+			Name: "client.admin",
+			Caps: map[string]string{
+				"mon": "allow *",
+			},
+		},
+		{
+			Name: "mon.",
+			Caps: map[string]string{
+				"mon": "allow *",
+			},
+		},
+	}
+
+	type testCase struct {
+		name     string
+		to       models.CephAuth
+		expOut   []models.CephAuthDifference
+		expError string
+	}
+
+	tcs := []testCase{
+		{
+			name: "entity by name and pattern",
+			to: models.CephAuth{
+				"client.rbd-prod": {
+					Caps: map[string]string{
+						"mgr": "profile rbd pool=rbd",
+						"mon": "profile rbd",
+						"osd": "profile rbd pool=rbd,profile rbd pool=images",
+					},
+				},
+				"client.rgw.*": {
+					Caps: map[string]string{
+						"mon": "allow rw",
+						"osd": "allow rwx",
+					},
+				},
+			},
+			expOut: []models.CephAuthDifference{
+				{
+					Kind:    models.CephAuthDifferenceKindRemove,
+					Entity:  "client.rbd-prod",
+					Daemon:  "mds",
+					OldCaps: "allow",
+				},
+				{
+					Kind:   models.CephAuthDifferenceKindAdd,
+					Entity: "client.rbd-prod",
+					Daemon: "mgr",
+					Caps:   "profile rbd pool=rbd",
+				},
+				{
+					Kind:    models.CephAuthDifferenceKindChange,
+					Entity:  "client.rbd-prod",
+					Daemon:  "osd",
+					OldCaps: "profile rbd pool=rbd",
+					Caps:    "profile rbd pool=rbd,profile rbd pool=images",
+				},
+				{
+					Kind:   models.CephAuthDifferenceKindAdd,
+					Entity: "client.rgw.nuc02",
+					Daemon: "osd",
+					Caps:   "allow rwx",
+				},
+			},
+		},
+		{
+			name: "name takes precedence over pattern",
+			to: models.CephAuth{
+				"client.rgw.*": {
+					Caps: map[string]string{
+						"mon": "allow rw",
+					},
+				},
+				"client.rgw.nuc01": {
+					Caps: map[string]string{
+						"mon": "allow rw",
+						"osd": "allow rwx",
+					},
+				},
+			},
+			expOut: []models.CephAuthDifference{},
+		},
+		{
+			name: "admin and monitor are not matched by patterns",
+			to: models.CephAuth{
+				"client.adm*": {
+					Caps: map[string]string{
+						"mon": "allow r",
+					},
+				},
+				"mon*": {
+					Caps: map[string]string{
+						"mon": "allow r",
+					},
+				},
+			},
+			expOut: []models.CephAuthDifference{},
+		},
+		{
+			name: "admin listed by name",
+			to: models.CephAuth{
+				"client.admin": {
+					Caps: map[string]string{
+						"mon": "allow *",
+						"osd": "allow *",
+					},
+				},
+			},
+			expOut: []models.CephAuthDifference{
+				{
+					Kind:   models.CephAuthDifferenceKindAdd,
+					Entity: "client.admin",
+					Daemon: "osd",
+					Caps:   "allow *",
+				},
+			},
+		},
+		{
+			name: "empty caps",
+			to: models.CephAuth{
+				"client.rgw.*": {},
+			},
+			expError: "`client.rgw.*`: auth entity caps are empty",
+		},
+		{
+			name: "unknown entity",
+			to: models.CephAuth{
+				"client.rbd-test": {
+					Caps: map[string]string{
+						"mon": "profile rbd",
+					},
+				},
+			},
+			expError: "`client.rbd-test`: unknown auth entity",
+		},
+		{
+			name: "entity matches several patterns",
+			to: models.CephAuth{
+				"client.rgw.*": {
+					Caps: map[string]string{
+						"mon": "allow rw",
+					},
+				},
+				"client.*.nuc01": {
+					Caps: map[string]string{
+						"mon": "allow rw",
+					},
+				},
+			},
+			expError: "`client.rgw.nuc01` matches client.*.nuc01, client.rgw.*: auth entity matches several patterns",
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephAuth(s.ctx, from, tc.to)
+			if tc.expError != "" {
+				r.Error(err)
+				r.Equal(tc.expError, err.Error())
+			} else {
+				r.NoError(err)
+				r.Equal(tc.expOut, diff)
+			}
+		})
+	}
+}
+
+func (s *differTestSuite) TestDiffCephFS() {
+	from := []models.FileSystem{
+		{
+			Name:               "shared-data",
+			MaxMDS:             1,
+			AllowStandbyReplay: false,
+			SessionTimeout:     60,
+			MaxFileSize:        1099511627776,
+			Joinable:           true,
+		},
+	}
+
+	type testCase struct {
+		name     string
+		to       models.CephFS
+		expOut   []models.CephFSDifference
+		expError string
+	}
+
+	tcs := []testCase{
+		{
+			name: "all settings changed",
+			to: models.CephFS{
+				"shared-data": {
+					MaxMDS:             ptr.Uint16(2),
+					AllowStandbyReplay: ptr.Bool(true),
+					SessionTimeout:     ptr.Uint32(120),
+					MaxFileSize:        ptr.Uint64(2199023255552),
+					Joinable:           ptr.Bool(false),
+				},
+			},
+			expOut: []models.CephFSDifference{
+				{FileSystem: "shared-data", Key: "max_mds", OldValue: "1", Value: "2"},
+				{FileSystem: "shared-data", Key: "allow_standby_replay", OldValue: "false", Value: "true"},
+				{FileSystem: "shared-data", Key: "session_timeout", OldValue: "60", Value: "120"},
+				{FileSystem: "shared-data", Key: "max_file_size", OldValue: "1099511627776", Value: "2199023255552"},
+				{FileSystem: "shared-data", Key: "joinable", OldValue: "true", Value: "false"},
+			},
+		},
+		{
+			name: "settings not listed are skipped",
+			to: models.CephFS{
+				"shared-data": {
+					MaxMDS: ptr.Uint16(1),
+				},
+			},
+			expOut: []models.CephFSDifference{},
+		},
+		{
+			name: "unknown filesystem",
+			to: models.CephFS{
+				"cephfs": {},
+			},
+			expError: "`cephfs`: unknown filesystem",
+		},
+	}
+
+	for _, tc := range tcs {
+		s.T().Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			diff, err := s.differ.DiffCephFS(s.ctx, from, tc.to)
+			if tc.expError != "" {
+				r.Error(err)
+				r.Equal(tc.expError, err.Error())
+			} else {
+				r.NoError(err)
+				r.Equal(tc.expOut, diff)
+			}
+		})
+	}
+}
+
 // Definitions ...
 
 type differTestSuite struct {
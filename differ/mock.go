@@ -27,3 +27,33 @@ func (m *Mock) DiffCephOSDConfig(ctx context.Context, from, to models.CephOSDCon
 	args := m.Called(from, to)
 	return args.Get(0).([]models.CephOSDConfigDifference), args.Error(1)
 }
+
+func (m *Mock) DiffCephOSDFlags(ctx context.Context, from, to models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephOSDFlagsDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephMgrModules(ctx context.Context, from models.MgrModules, to models.CephMgrModules) ([]models.CephMgrModulesDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephMgrModulesDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephBalancer(ctx context.Context, from, to models.CephBalancer) ([]models.CephBalancerDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephBalancerDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephHealthMutes(ctx context.Context, from []models.ClusterStatusMutedCheck, to models.CephHealthMutes) ([]models.CephHealthMutesDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephHealthMutesDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephAuth(ctx context.Context, from []models.AuthEntity, to models.CephAuth) ([]models.CephAuthDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephAuthDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephFS(ctx context.Context, from []models.FileSystem, to models.CephFS) ([]models.CephFSDifference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CephFSDifference), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package models
+
+// CephAuth is the set of CephX entities (`ceph auth`) by entity name or glob
+// pattern (i.e. `client.rgw.*`), entities not listed are left as is
+type CephAuth map[string]CephAuthEntity
+
+// CephAuthEntity is the intended state of CephX entity
+type CephAuthEntity struct {
+	Caps map[string]string `yaml:"caps" description:"Capabilities by daemon type (mon, osd, mgr, mds), the ones not listed are removed"`
+	// Key is never applied nor compared, it's only filled in by dump with
+	// secrets shown explicitly
+	Key string `yaml:"key,omitempty" description:"Entity key, ignored by diff and apply"`
+}
+
+// AuthEntity is the CephX entity reported by Ceph
+type AuthEntity struct {
+	Name string
+	Key  string
+	Caps map[string]string
+}
+
+type CephAuthDifferenceKind string
+
+const (
+	CephAuthDifferenceKindAdd    CephAuthDifferenceKind = "add"
+	CephAuthDifferenceKindChange CephAuthDifferenceKind = "change"
+	CephAuthDifferenceKindRemove CephAuthDifferenceKind = "remove"
+)
+
+type CephAuthDifference struct {
+	Kind    CephAuthDifferenceKind
+	Entity  string
+	Daemon  string
+	OldCaps string
+	Caps    string
+}
@@ -0,0 +1,22 @@
+package models
+
+// CephBalancer is the balancer configuration (`ceph balancer`)
+type CephBalancer struct {
+	Mode              string `yaml:"mode" default:"upmap" description:"Balancer mode" jsonschema:"enum=none|crush-compat|upmap|read|upmap-read"`
+	Active            bool   `yaml:"active" default:"true" description:"Whether balancer is active"`
+	UpmapMaxDeviation uint16 `yaml:"upmap_max_deviation" default:"5" description:"Maximum deviation of PGs amount on OSD from the target one for upmap mode" jsonschema:"minimum=1"`
+}
+
+// BalancerStatus is the balancer state reported by `ceph balancer status`
+type BalancerStatus struct {
+	Mode                 string
+	Active               bool
+	NoOptimizationNeeded bool
+	OptimizeResult       string
+}
+
+type CephBalancerDifference struct {
+	Key      string
+	OldValue string
+	Value    string
+}
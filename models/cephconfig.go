@@ -1,7 +1,25 @@
 package models
 
+import "strings"
+
+// CephConfig is the configuration by target: section optionally followed
+// by mask, i.e. `osd`, `osd.3` or `osd/class:ssd`, `osd/host:nuc01`
 type CephConfig map[string]map[string]string
 
+// ConfigTarget returns target for the section and mask (if any)
+func ConfigTarget(section, mask string) string {
+	if mask == "" {
+		return section
+	}
+	return section + "/" + mask
+}
+
+// ParseConfigTarget splits target into section and mask
+func ParseConfigTarget(target string) (section, mask string) {
+	section, mask, _ = strings.Cut(target, "/")
+	return section, mask
+}
+
 type CephConfigDifferenceKind string
 
 const (
@@ -13,7 +31,13 @@ const (
 type CephConfigDifference struct {
 	Kind     CephConfigDifferenceKind
 	Section  string
+	Mask     string
 	Key      string
 	OldValue *string
 	Value    *string
 }
+
+// Target returns section with mask the difference is related to
+func (d CephConfigDifference) Target() string {
+	return ConfigTarget(d.Section, d.Mask)
+}
@@ -0,0 +1,30 @@
+package models
+
+// CephFS is the set of per-filesystem settings (`ceph fs set`) by filesystem
+// name, filesystems and settings not listed are left as is
+type CephFS map[string]CephFSSettings
+
+type CephFSSettings struct {
+	MaxMDS             *uint16 `yaml:"max_mds,omitempty" description:"Amount of active MDS daemons" jsonschema:"minimum=1"`
+	AllowStandbyReplay *bool   `yaml:"allow_standby_replay,omitempty" description:"Allow standby MDS daemons to follow the journal of active ones"`
+	SessionTimeout     *uint32 `yaml:"session_timeout,omitempty" description:"Time in seconds after which unresponsive client session is considered stale" jsonschema:"minimum=30"`
+	MaxFileSize        *uint64 `yaml:"max_file_size,omitempty" description:"Maximum file size in bytes"`
+	Joinable           *bool   `yaml:"joinable,omitempty" description:"Allow MDS daemons to join the filesystem"`
+}
+
+// FileSystem is the filesystem settings reported by Ceph in FSMap
+type FileSystem struct {
+	Name               string
+	MaxMDS             uint16
+	AllowStandbyReplay bool
+	SessionTimeout     uint32
+	MaxFileSize        uint64
+	Joinable           bool
+}
+
+type CephFSDifference struct {
+	FileSystem string
+	Key        string
+	OldValue   string
+	Value      string
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// CephHealthMutes is the set of health check mutes (`ceph health mute`)
+// by check code, the mutes not listed are removed
+type CephHealthMutes map[string]CephHealthMute
+
+// CephHealthMute is the intended mute of the particular health check
+type CephHealthMute struct {
+	TTL           time.Duration `yaml:"ttl,omitempty" description:"Time the mute is set for (i.e. 4h), the mute is permanent if not set"`
+	Sticky        bool          `yaml:"sticky,omitempty" description:"Keep the mute even if the check is cleared"`
+	Justification string        `yaml:"justification,omitempty" description:"The reason the check is muted"`
+}
+
+type CephHealthMutesDifferenceKind string
+
+const (
+	CephHealthMutesDifferenceKindMute   CephHealthMutesDifferenceKind = "mute"
+	CephHealthMutesDifferenceKindChange CephHealthMutesDifferenceKind = "change"
+	CephHealthMutesDifferenceKindUnmute CephHealthMutesDifferenceKind = "unmute"
+)
+
+type CephHealthMutesDifference struct {
+	Kind CephHealthMutesDifferenceKind
+	Code string
+	Mute CephHealthMute
+	// ExpiresAt is the expiration time of the mute set on the cluster
+	ExpiresAt *time.Time
+}
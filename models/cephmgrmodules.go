@@ -0,0 +1,29 @@
+package models
+
+// CephMgrModules is the set of manager modules (`ceph mgr module`) which
+// must be enabled or disabled, modules missing in both lists are left as is
+type CephMgrModules struct {
+	Enabled  []string `yaml:"enabled,omitempty" description:"Modules which must be enabled"`
+	Disabled []string `yaml:"disabled,omitempty" description:"Modules which must be disabled, always-on modules could not be disabled"`
+}
+
+// MgrModules is the state of manager modules reported by Ceph
+type MgrModules struct {
+	// AlwaysOn are the modules which are always enabled and could not
+	// be disabled
+	AlwaysOn []string
+	Enabled  []string
+	Disabled []string
+}
+
+type CephMgrModulesDifferenceKind string
+
+const (
+	CephMgrModulesDifferenceKindEnable  CephMgrModulesDifferenceKind = "enable"
+	CephMgrModulesDifferenceKindDisable CephMgrModulesDifferenceKind = "disable"
+)
+
+type CephMgrModulesDifference struct {
+	Kind   CephMgrModulesDifferenceKind
+	Module string
+}
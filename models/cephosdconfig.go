@@ -1,11 +1,11 @@
 package models
 
 type CephOSDConfig struct {
-	AllowCrimson           bool    `yaml:"allow_crimson" diff:"allow_crimson" default:"false"`
-	BackfillfullRatio      float32 `yaml:"backfillfull_ratio" diff:"backfillfull_ratio" default:"0.9"`
-	FullRatio              float32 `yaml:"full_ratio" diff:"full_ratio" default:"0.95"`
-	NearfullRatio          float32 `yaml:"nearfull_ratio" diff:"nearfull_ratio" default:"0.85"`
-	RequireMinCompatClient string  `yaml:"require_min_compat_client" diff:"require_min_compat_client" default:"reef"`
+	AllowCrimson           bool    `yaml:"allow_crimson" diff:"allow_crimson" default:"false" description:"Allow crimson-osd daemons to boot"`
+	BackfillfullRatio      float32 `yaml:"backfillfull_ratio" diff:"backfillfull_ratio" default:"0.9" description:"Usage ratio OSD is considered too full to backfill" jsonschema:"minimum=0,maximum=1"`
+	FullRatio              float32 `yaml:"full_ratio" diff:"full_ratio" default:"0.95" description:"Usage ratio OSD is considered full and stops accepting writes" jsonschema:"minimum=0,maximum=1"`
+	NearfullRatio          float32 `yaml:"nearfull_ratio" diff:"nearfull_ratio" default:"0.85" description:"Usage ratio OSD is considered nearly full" jsonschema:"minimum=0,maximum=1"`
+	RequireMinCompatClient string  `yaml:"require_min_compat_client" diff:"require_min_compat_client" default:"reef" description:"Minimal Ceph release of the clients allowed to connect" jsonschema:"enum=firefly|hammer|jewel|kraken|luminous|mimic|nautilus|octopus|pacific|quincy|reef|squid|tentacle"`
 }
 
 type CephOSDConfigDifferenceKind string
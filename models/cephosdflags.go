@@ -0,0 +1,37 @@
+package models
+
+// CephOSDFlags is the set of OSD flags: cluster-wide ones (`ceph osd set`)
+// and the ones set for particular OSDs, CRUSH nodes or device classes
+// (`ceph osd set-group`)
+type CephOSDFlags struct {
+	Cluster       []string            `yaml:"cluster,omitempty" description:"Cluster-wide flags" jsonschema:"enum=noup|nodown|noout|noin|nobackfill|norebalance|norecover|noscrub|nodeep-scrub|notieragent|nosnaptrim|pauserd|pausewr"`
+	OSDs          map[string][]string `yaml:"osds,omitempty" description:"Flags by OSD name, i.e. osd.3" jsonschema:"enum=noup|nodown|noout|noin"`
+	CRUSHNodes    map[string][]string `yaml:"crush_nodes,omitempty" description:"Flags by CRUSH node (host, rack, etc.) name" jsonschema:"enum=noup|nodown|noout|noin"`
+	DeviceClasses map[string][]string `yaml:"device_classes,omitempty" description:"Flags by device class name" jsonschema:"enum=noup|nodown|noout|noin"`
+}
+
+// ClusterOSDFlags are the cluster-wide flags which could be set and unset,
+// permanent flags like `sortbitwise` are not the part of the list
+var ClusterOSDFlags = []string{
+	"noup", "nodown", "noout", "noin", "nobackfill", "norebalance", "norecover",
+	"noscrub", "nodeep-scrub", "notieragent", "nosnaptrim", "pauserd", "pausewr",
+}
+
+// GroupOSDFlags are the flags which could be set for OSDs, CRUSH nodes
+// and device classes
+var GroupOSDFlags = []string{"noup", "nodown", "noout", "noin"}
+
+type CephOSDFlagsDifferenceKind string
+
+const (
+	CephOSDFlagsDifferenceKindSet   CephOSDFlagsDifferenceKind = "set"
+	CephOSDFlagsDifferenceKindUnset CephOSDFlagsDifferenceKind = "unset"
+)
+
+type CephOSDFlagsDifference struct {
+	Kind CephOSDFlagsDifferenceKind
+	// Who is the OSD, CRUSH node or device class name, empty
+	// for cluster-wide flags
+	Who  string
+	Flag string
+}
@@ -0,0 +1,16 @@
+package models
+
+type HostFacts struct {
+	NumOSDs           uint16
+	MemoryTotalBytes  uint64
+	MemoryPerOSDBytes uint64
+}
+
+// ClusterFacts are the values computed from the cluster state available
+// to use in specification templates
+type ClusterFacts struct {
+	NumOSDs              uint16
+	NumHosts             uint16
+	MinMemoryPerOSDBytes uint64
+	Hosts                map[string]HostFacts
+}
@@ -50,6 +50,98 @@ const (
 	// Dangerous: collisions found
 	ClusterHealthIndicatorTypeIPCollision ClusterHealthIndicatorType = "IP_COLLISION"
 
+	// ClusterHealthIndicatorTypeOSDsWithoutClusterAddress reflects amount of OSDs without cluster address
+	//
+	// Description: OSDs which have no cluster address in OSD map can't
+	// 	take part in replication and recovery, usually they've never
+	// 	booted successfully.
+	//
+	// Good: 0
+	// AtRisk: >0
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeOSDsWithoutClusterAddress ClusterHealthIndicatorType = "OSD_NO_CLUSTER_ADDRESS"
+
+	// ClusterHealthIndicatorTypeOSDsSharedNetwork reflects OSDs which public and cluster addresses share a network
+	//
+	// Description: OSDs which front (public) and back (cluster) addresses
+	// 	are the same or back address belongs to the same public_network
+	// 	subnet as the front one mix client and replication traffic so
+	// 	recovery competes with client IO.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/configuration/network-config-ref/
+	//
+	// Good: none
+	// AtRisk: at least 1 OSD with public and cluster addresses in the same network
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeOSDsSharedNetwork ClusterHealthIndicatorType = "OSD_SHARED_NETWORK"
+
+	// ClusterHealthIndicatorTypeOSDsHeartbeatAddress reflects OSDs which heartbeat addresses don't match the data ones
+	//
+	// Description: OSD heartbeats are expected to go through the same
+	// 	front and back addresses as the data, otherwise failure of the
+	// 	data network could stay unnoticed by the heartbeats.
+	//
+	// Good: none
+	// AtRisk: at least 1 OSD with heartbeat address mismatch
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeOSDsHeartbeatAddress ClusterHealthIndicatorType = "OSD_HEARTBEAT_ADDRESS"
+
+	// ClusterHealthIndicatorTypeMaintenance reflects maintenance started
+	// 	by `cephctl maintenance start`
+	//
+	// Description: maintenance sets flags like noout which prevent cluster
+	// 	from recovery so it must be ended as soon as possible. Maintenance
+	// 	lease past its expiration time is most likely forgotten.
+	//
+	// Good: no maintenance
+	// AtRisk: maintenance in progress
+	// Dangerous: maintenance lease expired
+	ClusterHealthIndicatorTypeMaintenance ClusterHealthIndicatorType = "MAINTENANCE"
+
+	// ClusterHealthIndicatorTypeBalancer reflects balancer state
+	//
+	// Description: upmap mode distributes PGs across OSDs much more evenly
+	// 	than crush-compat one but requires clients of luminous or newer, so
+	// 	it's checked only when RequireMinCompatClient allows upmap.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/balancer/
+	//
+	// Good: balancer is active in upmap mode or upmap is not allowed
+	// AtRisk: balancer is off or in mode other than upmap
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeBalancer ClusterHealthIndicatorType = "BALANCER"
+
+	// ClusterHealthIndicatorTypePoolRedundancy reflects pools with risky size settings
+	//
+	// Description: replicated pool with min_size=1 or erasure coded pool with
+	// 	min_size=k accepts writes with no redundancy left so any other failure
+	// 	loses data. Replicated pool with size=2 has no margin while one of the
+	// 	copies is recovering, erasure coded pool with min_size>k+1 stops IO
+	// 	earlier than it has to.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/pools/#setting-the-number-of-rados-object-replicas
+	//
+	// Good: replicated pools have size>=3 and min_size>=2, erasure coded pools have min_size=k+1
+	// AtRisk: at least 1 replicated pool with size<3 or erasure coded pool with min_size>k+1
+	// Dangerous: at least 1 replicated pool with min_size<2 or erasure coded pool with min_size<=k
+	ClusterHealthIndicatorTypePoolRedundancy ClusterHealthIndicatorType = "POOL_REDUNDANCY"
+
+	// ClusterHealthIndicatorTypeFailureDomainCapacity reflects if pools could survive
+	// the loss of their largest failure domain bucket
+	//
+	// Description: when the whole host (rack, etc.) goes away its data is
+	// 	recovered to the remaining buckets of the same CRUSH rule. If there's
+	// 	not enough buckets left or OSDs become full on recovery the cluster
+	// 	stays degraded or even stops IO. Pool usage is distributed among OSDs
+	// 	proportionally to CRUSH weights so the value is an estimation only.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/monitoring-osd-pg/#storage-capacity
+	//
+	// Good: the worst pool's OSDs stay below nearfull_ratio after the loss
+	// AtRisk: the worst pool's OSDs cross nearfull_ratio after the loss
+	// Dangerous: the worst pool's OSDs cross full_ratio after the loss or there's not enough failure domains left
+	ClusterHealthIndicatorTypeFailureDomainCapacity ClusterHealthIndicatorType = "FAILURE_DOMAIN_CAPACITY"
+
 	// ClusterHealthIndicatorTypeMonsDown reflects amount of monitor nodes which are down
 	//
 	// Description: amount of monitors which are not up at the moment
@@ -59,6 +151,41 @@ const (
 	// Dangerous: n/a
 	ClusterHealthIndicatorTypeMonsDown ClusterHealthIndicatorType = "MON_DOWN"
 
+	// ClusterHealthIndicatorTypeDegradedObjects reflects ratio of degraded objects
+	//
+	// Description: degraded objects have less replicas (or EC chunks) than the
+	// 	pool requires so another failure could make them unavailable or lost.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/health-checks/#pg-degraded
+	//
+	// Good: 0%
+	// AtRisk: >0%
+	// Dangerous: >=10%
+	ClusterHealthIndicatorTypeDegradedObjects ClusterHealthIndicatorType = "DEGRADED_OBJECTS"
+
+	// ClusterHealthIndicatorTypeMisplacedObjects reflects ratio of misplaced objects
+	//
+	// Description: misplaced objects are fully redundant but stored not where
+	// 	CRUSH wants them to be so they're going to be moved causing extra
+	// 	load on the cluster.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/monitoring-osd-pg/#monitoring-pg-states
+	//
+	// Good: 0%
+	// AtRisk: >0%
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeMisplacedObjects ClusterHealthIndicatorType = "MISPLACED_OBJECTS"
+
+	// ClusterHealthIndicatorTypeClientIO reflects client IO rates
+	//
+	// Description: read and write rates of the clients, informational only
+	// 	to put the other indicators into context.
+	//
+	// Good: any
+	// AtRisk: n/a
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeClientIO ClusterHealthIndicatorType = "CLIENT_IO"
+
 	// ClusterHealthIndicatorTypeMutesAmount reflects amount of mutes set on the cluster
 	//
 	// Description: Ceph allows to mute checks i.e. exclude them from triggering
@@ -118,6 +245,152 @@ const (
 	// Dangerous: n/a
 	ClusterHealthIndicatorTypeOSDsOut ClusterHealthIndicatorType = "OSD_OUT"
 
+	// ClusterHealthIndicatorTypeOSDsLaggy reflects OSDs which are laggy or flapping
+	//
+	// Description: laggy probability grows each time OSD is marked down
+	// 	while being alive i.e. missed heartbeats because of failing disk,
+	// 	NIC or overloaded host. Such OSDs cause slow ops and peering on
+	// 	each of down/up transitions. OSD marked down and up again within
+	// 	the last hour is considered flapping regardless of the probability.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/troubleshooting/troubleshooting-osd/#flapping-osds
+	//
+	// Good: no OSDs with laggy probability >= 50% and no flapping OSDs
+	// AtRisk: at least 1 OSD with laggy probability >= 50% or flapping
+	// Dangerous: at least 1 OSD with laggy probability >= 80% or laggy OSD flapping
+	ClusterHealthIndicatorTypeOSDsLaggy ClusterHealthIndicatorType = "OSD_LAGGY"
+
+	// ClusterHealthIndicatorTypeDaemonVersions reflects versions skew across
+	// 	all of the daemon types
+	//
+	// Description: different versions of daemons are normal only while
+	// 	upgrade is in progress. Upgrade is performed in order: mon and mgr
+	// 	(in any order between them), osd, mds, rgw so any daemon newer than
+	// 	the oldest daemon of the type upgraded before it (i.e. OSDs newer
+	// 	than mons) means the order was violated.
+	//
+	// Ref: https://docs.ceph.com/en/latest/cephadm/upgrade/
+	//
+	// Good: all daemons run the same version
+	// AtRisk: more than 1 version is running
+	// Dangerous: upgrade order is violated
+	ClusterHealthIndicatorTypeDaemonVersions ClusterHealthIndicatorType = "DAEMON_VERSIONS"
+
+	// ClusterHealthIndicatorTypeReleaseFlags reflects release flags which were
+	// 	not raised after upgrade
+	//
+	// Description: require_osd_release lower than the lowest release of
+	// 	running OSDs prevents new features from being used and allows older
+	// 	OSDs to join the cluster. require_min_compat_client lower than the
+	// 	lowest release of running OSDs allows older clients to connect and
+	// 	prevents features requiring newer clients (i.e. upmap) from being
+	// 	used. Indicator is unknown when flag is not set or its value is not
+	// 	a known release.
+	//
+	// Ref: https://docs.ceph.com/en/latest/cephadm/upgrade/#post-upgrade
+	//
+	// Good: flags match the releases in use
+	// AtRisk: at least 1 flag is lower than the release in use
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeReleaseFlags ClusterHealthIndicatorType = "RELEASE_FLAGS"
+
+	// ClusterHealthIndicatorTypeCRUSHTunables reflects CRUSH tunables profile
+	// 	older than the running releases support
+	//
+	// Description: legacy and older tunables profiles produce worse data
+	// 	distribution and more data movement on topology changes. Newer
+	// 	profile is available when both OSDs and clients allowed to connect
+	// 	(require_min_compat_client) support it. Switching the profile remaps
+	// 	PGs and moves data so the value contains rough estimate of PGs to
+	// 	remap and the amount of data to move based on the average PG size.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/crush-map/#tunables
+	//
+	// Good: the newest supported profile is in use
+	// AtRisk: newer profile is available or legacy tunables are in use
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeCRUSHTunables ClusterHealthIndicatorType = "CRUSH_TUNABLES"
+
+	// ClusterHealthIndicatorTypeOSDsUtilizationSpread reflects the difference between
+	// 	the most and the least utilized OSDs in percents
+	//
+	// Description: imbalanced OSDs fill up long before the total cluster usage
+	// 	looks worrying since the first full OSD blocks writes to all of its PGs.
+	// 	OSDs with zero reweight are not taken into account since they're
+	// 	drained.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/operations/balancer/
+	//
+	// Good: <=10
+	// AtRisk: >10
+	// Dangerous: >20
+	ClusterHealthIndicatorTypeOSDsUtilizationSpread ClusterHealthIndicatorType = "OSD_UTILIZATION_SPREAD"
+
+	// ClusterHealthIndicatorTypeOSDsFullness reflects OSDs approaching nearfull
+	// 	and backfillfull ratios
+	//
+	// Description: OSDs which are less than 5% away from nearfull_ratio are going
+	// 	to raise OSD_NEARFULL soon, the ones less than 5% away from
+	// 	backfillfull_ratio are about to block backfill to them.
+	//
+	// Ref: https://docs.ceph.com/en/latest/rados/configuration/mon-config-ref/#storage-capacity
+	//
+	// Good: none
+	// AtRisk: at least 1 OSD with utilization >= nearfull_ratio - 5%
+	// Dangerous: at least 1 OSD with utilization >= backfillfull_ratio - 5%
+	ClusterHealthIndicatorTypeOSDsFullness ClusterHealthIndicatorType = "OSD_FULLNESS"
+
+	// ClusterHealthIndicatorTypeHostSwap reflects OSD hosts with swap enabled
+	//
+	// Description: swapped out OSD memory causes latency spikes and could
+	// 	lead to heartbeat timeouts so OSD hosts shouldn't have swap enabled.
+	//
+	// Good: none
+	// AtRisk: at least 1 host with swap enabled
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeHostSwap ClusterHealthIndicatorType = "HOST_SWAP"
+
+	// ClusterHealthIndicatorTypeHostArchitectures reflects CPU architectures of OSD hosts
+	//
+	// Description: mixed CPU architectures complicate hardware and software
+	// 	maintenance and make performance of the hosts hard to compare.
+	//
+	// Good: single architecture
+	// AtRisk: more than one architecture
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeHostArchitectures ClusterHealthIndicatorType = "HOST_ARCHITECTURES"
+
+	// ClusterHealthIndicatorTypeHostKernelVersions reflects kernel versions of OSD hosts
+	//
+	// Description: different kernel versions usually mean an unfinished
+	// 	rollout and may cause different behaviour of the hosts.
+	//
+	// Good: single kernel version
+	// AtRisk: more than one kernel version
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeHostKernelVersions ClusterHealthIndicatorType = "HOST_KERNEL_VERSIONS"
+
+	// ClusterHealthIndicatorTypeHostDistroVersions reflects OS distributions of OSD hosts
+	//
+	// Description: different distributions or their versions usually mean
+	// 	an unfinished rollout and may cause different behaviour of the hosts.
+	//
+	// Good: single distribution version
+	// AtRisk: more than one distribution version
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeHostDistroVersions ClusterHealthIndicatorType = "HOST_DISTRO_VERSIONS"
+
+	// ClusterHealthIndicatorTypeOSDsNUMAUnknown reflects OSDs with unknown NUMA affinity
+	//
+	// Description: OSDs which network interfaces or devices NUMA node is
+	// 	unknown can't be pinned to the NUMA node so memory and interrupts
+	// 	could cross NUMA nodes on multi-socket hosts.
+	//
+	// Good: none
+	// AtRisk: at least 1 OSD with unknown NUMA node of network interface or device
+	// Dangerous: n/a
+	ClusterHealthIndicatorTypeOSDsNUMAUnknown ClusterHealthIndicatorType = "OSD_NUMA_UNKNOWN"
+
 	// ClusterHealthIndicatorTypeQuorum reflects monitor quorum status
 	//
 	// Description: monitors in quorum which should be the same as total
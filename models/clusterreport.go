@@ -1,26 +1,69 @@
 package models
 
+import "time"
+
 type OSDDaemon struct {
 	ID               uint16
 	Hostname         string
 	Architecture     string
+	KernelVersion    string
+	Distro           string
+	DistroVersion    string
 	FrontIP          string
 	BackIP           string
+	HeartbeatFrontIP string
+	HeartbeatBackIP  string
+	CapacityBytes    uint64
 	MemoryTotalBytes uint64
 	SwapTotalBytes   uint64
 	IsRotational     bool
-	Devices          []string
+	// NUMAUnknownIfaces and NUMAUnknownDevices are the network interfaces and
+	// the devices Ceph was unable to determine NUMA node of
+	NUMAUnknownIfaces  []string
+	NUMAUnknownDevices []string
+	Devices            []string
+}
+
+// OSDUsage is the space usage of particular OSD as reported by `ceph osd df`
+type OSDUsage struct {
+	ID          uint16
+	DeviceClass string
+	CapacityKB  uint64
+	UsedKB      uint64
+	AvailableKB uint64
+	// Utilization is the ratio of used space in range [0, 1]
+	Utilization float64
+	Reweight    float64
+	NumPGs      uint32
+	Status      string
+}
+
+// OSDState is the state of particular OSD as recorded in OSD map
+type OSDState struct {
+	ID     uint16
+	Up     bool
+	In     bool
+	UpFrom uint32
+	DownAt uint32
+	// DownStamp is the time OSD was marked down last time, zero if never
+	DownStamp time.Time
+	// LaggyProbability is the probability in range [0, 1] the OSD was
+	// marked down while being alive, grows on each of such events
+	LaggyProbability float64
+	LaggyInterval    time.Duration
 }
 
 type ClusterReport struct {
 	AllowCrimson                 bool
 	BackfillfullRatio            float32
+	CRUSHMap                     CRUSHMap
 	Checks                       []ClusterStatusCheck
-	Devices                      []Device
 	FullRatio                    float32
 	HealthStatus                 ClusterStatusHealth
+	MinCompatClient              string
 	MutedChecks                  []ClusterStatusMutedCheck
 	NearfullRatio                float32
+	NumDaemonsByVersion          map[string]map[string]uint16
 	NumMons                      uint8
 	NumMonsInQuorum              uint8
 	NumOSDs                      uint16
@@ -34,7 +77,11 @@ type ClusterReport struct {
 	NumPGsByState                map[string]uint32
 	NumPools                     uint16
 	OSDDaemons                   []OSDDaemon
+	OSDFlags                     CephOSDFlags
+	OSDStates                    []OSDState
+	Pools                        []Pool
 	RequireMinCompatClient       string
+	RequireOSDRelease            string
 	StretchMode                  bool
 	TotalOSDCapacityKB           uint64
 	TotalOSDUsedDataKB           uint64
@@ -0,0 +1,14 @@
+package models
+
+// ClusterSnapshot is the combined cluster state healthchecks are performed
+// against: `ceph report` and `ceph status` along with the data available
+// via separate commands only. The data which was not retrieved is nil
+type ClusterSnapshot struct {
+	Report      ClusterReport
+	Status      ClusterStatus
+	Devices     []Device
+	Maintenance *MaintenanceLease
+	Balancer    *BalancerStatus
+	OSDUsage    []OSDUsage
+	Config      CephConfig
+}
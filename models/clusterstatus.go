@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type ClusterStatusHealth string
 
 const (
@@ -12,6 +14,10 @@ const (
 type ClusterStatusMutedCheck struct {
 	Code    string
 	Summary string
+	// Sticky mutes are kept even if the check is cleared
+	Sticky bool
+	// ExpiresAt is nil for the mutes set without TTL
+	ExpiresAt *time.Time
 }
 
 type ClusterStatusCheck struct {
@@ -32,4 +38,13 @@ type ClusterStatus struct {
 	OSDsDownAmount uint
 	UncleanPGs     uint
 	InactivePGs    uint
+	// DegradedRatio and MisplacedRatio are the fractions of objects
+	// in 0..1 range
+	DegradedRatio  float64
+	MisplacedRatio float64
+
+	ClientReadBytesPerSec  uint64
+	ClientWriteBytesPerSec uint64
+	ClientReadOpsPerSec    uint64
+	ClientWriteOpsPerSec   uint64
 }
@@ -0,0 +1,40 @@
+package models
+
+type CRUSHMap struct {
+	Buckets  []CRUSHBucket
+	Rules    []CRUSHRule
+	Tunables CRUSHTunables
+}
+
+// CRUSHTunables is the tunables profile summary, Profile is `unknown`
+// when tunables were customized and don't match any profile
+type CRUSHTunables struct {
+	Profile                string
+	OptimalTunables        bool
+	LegacyTunables         bool
+	MinimumRequiredVersion string
+}
+
+type CRUSHBucket struct {
+	ID    int32
+	Name  string
+	Type  string
+	Items []CRUSHBucketItem
+}
+
+// CRUSHBucketItem is a child of the bucket: another bucket when ID is
+// negative or OSD otherwise. Weight is in TiB just like in `ceph osd tree`
+type CRUSHBucketItem struct {
+	ID     int32
+	Weight float64
+}
+
+// CRUSHRule is a simplified representation of CRUSH rule: Root is the bucket
+// taken by the first `take` step and FailureDomain is the bucket type
+// of the first `choose*` step
+type CRUSHRule struct {
+	ID            int
+	Name          string
+	Root          int32
+	FailureDomain string
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// MaintenanceLease is the record of maintenance in progress which allows
+// to restore the flags set for maintenance and to find out the maintenance
+// was forgotten
+type MaintenanceLease struct {
+	// Who is the CRUSH node (host) or OSD (i.e. osd.3) under maintenance
+	Who string
+	// Flags are the flags set when maintenance was started, the ones set
+	// before are not the part of the lease since they must stay as is
+	Flags     CephOSDFlags
+	StartedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsExpired reports whether the lease has expired at the given time
+func (l MaintenanceLease) IsExpired(now time.Time) bool {
+	return now.After(l.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package models
+
+// OSDOkToStop reports whether OSDs could be stopped without PGs becoming
+// inactive
+type OSDOkToStop struct {
+	OkToStop    bool
+	OSDs        []uint16
+	NumOkPGs    uint32
+	NumNotOkPGs uint32
+	// BadBecomeInactive are the PGs which would become inactive
+	BadBecomeInactive []string
+	// OkBecomeDegraded are the PGs which would become degraded but
+	// remain active
+	OkBecomeDegraded []string
+}
+
+// OSDSafeToDestroy reports whether OSDs could be destroyed without
+// reducing data durability
+type OSDSafeToDestroy struct {
+	SafeToDestroy []uint16
+	// Active are the OSDs which are still up
+	Active []uint16
+	// MissingStats are the OSDs PG stats are not reported for yet
+	MissingStats []uint16
+	// StoredPGs are the OSDs which still store PGs
+	StoredPGs []uint16
+}
+
+// OkToStopVerdict is the aggregate go/no-go answer whether the host or OSD
+// could be stopped with the reasons if it could not. SafeToDestroy is only
+// evaluated when destroy is requested
+type OkToStopVerdict struct {
+	Who           string
+	OSDs          []uint16
+	Go            bool
+	SafeToDestroy bool
+	Reasons       []string
+}
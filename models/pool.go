@@ -0,0 +1,25 @@
+package models
+
+type PoolType string
+
+const (
+	PoolTypeReplicated PoolType = "replicated"
+	PoolTypeErasure    PoolType = "erasure"
+)
+
+type Pool struct {
+	ID      uint32
+	Name    string
+	Type    PoolType
+	Size    uint8
+	MinSize uint8
+	// CrushRule is the ID of CRUSH rule used by the pool
+	CrushRule int
+	// ErasureCodeK and ErasureCodeM are the amount of data and coding chunks
+	// set for erasure coded pools only
+	ErasureCodeK uint8
+	ErasureCodeM uint8
+	// AllocatedBytes is the raw space allocated by the pool including
+	// replicas and coding chunks
+	AllocatedBytes uint64
+}
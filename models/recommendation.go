@@ -0,0 +1,14 @@
+package models
+
+// OSDMemoryRecommendation is the recommended osd_memory_target for the host
+type OSDMemoryRecommendation struct {
+	Hostname             string
+	NumOSDs              uint16
+	NumRotationalOSDs    uint16
+	MemoryTotalBytes     uint64
+	OSDMemoryTargetBytes uint64
+
+	// Insufficient is set when the host has not enough memory for its OSDs
+	// so the target is set to the minimal value allowed by Ceph
+	Insufficient bool
+}
@@ -0,0 +1,16 @@
+package models
+
+import "slices"
+
+// CephReleases are Ceph release names in order of appearance
+var CephReleases = []string{
+	"argonaut", "bobtail", "cuttlefish", "dumpling", "emperor", "firefly",
+	"giant", "hammer", "infernalis", "jewel", "kraken", "luminous", "mimic",
+	"nautilus", "octopus", "pacific", "quincy", "reef", "squid", "tentacle",
+}
+
+// CompareCephReleases compares releases by their order, unknown release
+// is considered older than any known one
+func CompareCephReleases(a, b string) int {
+	return slices.Index(CephReleases, a) - slices.Index(CephReleases, b)
+}
@@ -7,15 +7,15 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func AllowCrimson(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func AllowCrimson(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
-	if cr.AllowCrimson {
+	if cs.Report.AllowCrimson {
 		st = models.ClusterHealthIndicatorStatusAtRisk
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeAllowCrimson,
-		CurrentValue:       strconv.FormatBool(cr.AllowCrimson),
+		CurrentValue:       strconv.FormatBool(cs.Report.AllowCrimson),
 		CurrentValueStatus: st,
 	}, nil
 }
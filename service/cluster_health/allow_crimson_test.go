@@ -13,8 +13,10 @@ func TestAllowCrimson(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "crimson is allowed",
-			in: models.ClusterReport{
-				AllowCrimson: true,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					AllowCrimson: true,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeAllowCrimson,
@@ -24,8 +26,10 @@ func TestAllowCrimson(t *testing.T) {
 		},
 		{
 			name: "crimson is disallowed",
-			in: models.ClusterReport{
-				AllowCrimson: false,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					AllowCrimson: false,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeAllowCrimson,
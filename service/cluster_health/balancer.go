@@ -0,0 +1,38 @@
+package cluster_health
+
+import (
+	"context"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// upmapMinCompatClient is the oldest client release supporting upmap
+const upmapMinCompatClient = "luminous"
+
+func Balancer(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if cs.Balancer == nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+			CurrentValue:       "balancer status is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	value := cs.Balancer.Mode
+	if !cs.Balancer.Active {
+		value = "off"
+	}
+
+	status := models.ClusterHealthIndicatorStatusGood
+	if models.CompareCephReleases(cs.Report.RequireMinCompatClient, upmapMinCompatClient) >= 0 {
+		if !cs.Balancer.Active || (cs.Balancer.Mode != "upmap" && cs.Balancer.Mode != "upmap-read") {
+			status = models.ClusterHealthIndicatorStatusAtRisk
+		}
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+		CurrentValue:       value,
+		CurrentValueStatus: status,
+	}, nil
+}
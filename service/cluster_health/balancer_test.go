@@ -0,0 +1,106 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestBalancer(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "active upmap",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					RequireMinCompatClient: "reef",
+				},
+				Balancer: &models.BalancerStatus{
+					Mode:   "upmap",
+					Active: true,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+				CurrentValue:       "upmap",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "balancer is off",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					RequireMinCompatClient: "luminous",
+				},
+				Balancer: &models.BalancerStatus{
+					Mode:   "upmap",
+					Active: false,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+				CurrentValue:       "off",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "crush-compat mode",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					RequireMinCompatClient: "squid",
+				},
+				Balancer: &models.BalancerStatus{
+					Mode:   "crush-compat",
+					Active: true,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+				CurrentValue:       "crush-compat",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "upmap is not allowed",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					RequireMinCompatClient: "jewel",
+				},
+				Balancer: &models.BalancerStatus{
+					Mode:   "crush-compat",
+					Active: true,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+				CurrentValue:       "crush-compat",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "balancer status is not available",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					RequireMinCompatClient: "reef",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+				CurrentValue:       "balancer status is not available",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := Balancer(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package cluster_health
+
+import "strconv"
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+// formatBytes returns human readable amount of bytes in binary units
+func formatBytes(v uint64) string {
+	value := float64(v)
+	unit := 0
+	for value >= 1024 && unit < len(byteUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return strconv.FormatUint(v, 10) + " " + byteUnits[0]
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + byteUnits[unit]
+}
@@ -0,0 +1,20 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func ClientIO(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	return models.ClusterHealthIndicator{
+		Indicator: models.ClusterHealthIndicatorTypeClientIO,
+		CurrentValue: fmt.Sprintf(
+			"rd: %s/s, %d op/s; wr: %s/s, %d op/s",
+			formatBytes(cs.Status.ClientReadBytesPerSec), cs.Status.ClientReadOpsPerSec,
+			formatBytes(cs.Status.ClientWriteBytesPerSec), cs.Status.ClientWriteOpsPerSec,
+		),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestClientIO(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "idle cluster",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeClientIO,
+				CurrentValue:       "rd: 0 B/s, 0 op/s; wr: 0 B/s, 0 op/s",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "busy cluster",
+			in: models.ClusterSnapshot{
+				Status: models.ClusterStatus{
+					ClientReadBytesPerSec:  15184,
+					ClientWriteBytesPerSec: 3 << 30,
+					ClientReadOpsPerSec:    16,
+					ClientWriteOpsPerSec:   2200,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeClientIO,
+				CurrentValue:       "rd: 14.8 KiB/s, 16 op/s; wr: 3.0 GiB/s, 2200 op/s",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := ClientIO(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -6,4 +6,4 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-type ClusterHealthCheck func(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error)
+type ClusterHealthCheck func(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error)
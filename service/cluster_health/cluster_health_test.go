@@ -6,6 +6,6 @@ import (
 
 type testCase struct {
 	name   string
-	in     models.ClusterReport
+	in     models.ClusterSnapshot
 	expOut models.ClusterHealthIndicator
 }
@@ -6,33 +6,33 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func ClusterStatus(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func ClusterStatus(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	const indicator = models.ClusterHealthIndicatorTypeClusterStatus
 
-	switch cr.HealthStatus {
+	switch cs.Report.HealthStatus {
 	case models.ClusterStatusHealthOK:
 		return models.ClusterHealthIndicator{
 			Indicator:          indicator,
-			CurrentValue:       string(cr.HealthStatus),
+			CurrentValue:       string(cs.Report.HealthStatus),
 			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
 		}, nil
 	case models.ClusterStatusHealthWARN:
 		return models.ClusterHealthIndicator{
 			Indicator:          indicator,
-			CurrentValue:       string(cr.HealthStatus),
+			CurrentValue:       string(cs.Report.HealthStatus),
 			CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
 		}, nil
 	case models.ClusterStatusHealthERR:
 		return models.ClusterHealthIndicator{
 			Indicator:          indicator,
-			CurrentValue:       string(cr.HealthStatus),
+			CurrentValue:       string(cs.Report.HealthStatus),
 			CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
 		}, nil
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          indicator,
-		CurrentValue:       string(cr.HealthStatus),
+		CurrentValue:       string(cs.Report.HealthStatus),
 		CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
 	}, nil
 }
@@ -13,8 +13,10 @@ func TestClusterStatus(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "HEALTH_OK",
-			in: models.ClusterReport{
-				HealthStatus: models.ClusterStatusHealthOK,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					HealthStatus: models.ClusterStatusHealthOK,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeClusterStatus,
@@ -24,8 +26,10 @@ func TestClusterStatus(t *testing.T) {
 		},
 		{
 			name: "HEALTH_WARN",
-			in: models.ClusterReport{
-				HealthStatus: models.ClusterStatusHealthWARN,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					HealthStatus: models.ClusterStatusHealthWARN,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeClusterStatus,
@@ -35,8 +39,10 @@ func TestClusterStatus(t *testing.T) {
 		},
 		{
 			name: "HEALTH_ERR",
-			in: models.ClusterReport{
-				HealthStatus: models.ClusterStatusHealthERR,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					HealthStatus: models.ClusterStatusHealthERR,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeClusterStatus,
@@ -46,8 +52,10 @@ func TestClusterStatus(t *testing.T) {
 		},
 		{
 			name: "RANDOM_VALUE",
-			in: models.ClusterReport{
-				HealthStatus: models.ClusterStatusHealthUnknown,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					HealthStatus: models.ClusterStatusHealthUnknown,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeClusterStatus,
@@ -0,0 +1,108 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// crushTunablesProfiles are CRUSH tunables profiles in order of appearance
+// with rough share of PGs remapped when switching to the profile from
+// the previous one
+//
+// Ref: https://docs.ceph.com/en/latest/rados/operations/crush-map/#tunables
+var crushTunablesProfiles = []struct {
+	name         string
+	dataMovement float64
+}{
+	{name: "argonaut", dataMovement: 0},
+	{name: "bobtail", dataMovement: 0.1},
+	{name: "firefly", dataMovement: 0.1},
+	{name: "hammer", dataMovement: 0.05},
+	{name: "jewel", dataMovement: 0.15},
+}
+
+func CRUSHTunables(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.NumOSDsByRelease) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+			CurrentValue:       "no OSD releases found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	// Profile change requires both OSDs and clients to support it
+	minRelease := cs.Report.RequireMinCompatClient
+	for release := range cs.Report.NumOSDsByRelease {
+		if minRelease == "" || models.CompareCephReleases(release, minRelease) < 0 {
+			minRelease = release
+		}
+	}
+
+	target := 0
+	for i, p := range crushTunablesProfiles {
+		if models.CompareCephReleases(p.name, minRelease) <= 0 {
+			target = i
+		}
+	}
+
+	current := crushTunablesProfileIndex(cs.Report.CRUSHMap.Tunables)
+	currentName := crushTunablesProfiles[current].name
+	if cs.Report.CRUSHMap.Tunables.LegacyTunables {
+		currentName += " (legacy)"
+	}
+
+	if current >= target {
+		st := models.ClusterHealthIndicatorStatusGood
+		if cs.Report.CRUSHMap.Tunables.LegacyTunables {
+			st = models.ClusterHealthIndicatorStatusAtRisk
+		}
+
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+			CurrentValue:       currentName,
+			CurrentValueStatus: st,
+		}, nil
+	}
+
+	kept := 1.0
+	for _, p := range crushTunablesProfiles[current+1 : target+1] {
+		kept *= 1 - p.dataMovement
+	}
+	moved := 1 - kept
+
+	// Data moved is estimated as amount of remapped PGs multiplied by
+	// the average PG size
+	movedPGs := uint32(math.Round(moved * float64(cs.Report.NumPGs)))
+	movedBytes := uint64(0)
+	if cs.Report.NumPGs > 0 {
+		movedBytes = cs.Report.TotalOSDUsedDataKB * 1024 / uint64(cs.Report.NumPGs) * uint64(movedPGs)
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator: models.ClusterHealthIndicatorTypeCRUSHTunables,
+		CurrentValue: fmt.Sprintf("%s -> %s available, ~%d of %d PGs (~%s) to remap",
+			currentName, crushTunablesProfiles[target].name, movedPGs, cs.Report.NumPGs, formatBytes(movedBytes)),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
+
+// crushTunablesProfileIndex returns index of the current profile in
+// crushTunablesProfiles. Customized tunables are matched by the minimum
+// required version since the profile is reported as `unknown` for them
+func crushTunablesProfileIndex(t models.CRUSHTunables) int {
+	if t.LegacyTunables {
+		return 0
+	}
+
+	for _, name := range []string{t.Profile, t.MinimumRequiredVersion} {
+		for i, p := range crushTunablesProfiles {
+			if p.name == name {
+				return i
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestCRUSHTunables(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "optimal tunables",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "reef",
+					NumPGs:                 330,
+					CRUSHMap: models.CRUSHMap{
+						Tunables: models.CRUSHTunables{
+							Profile:                "jewel",
+							OptimalTunables:        true,
+							MinimumRequiredVersion: "jewel",
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "jewel",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "newer profile is available",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "luminous",
+					NumPGs:                 1000,
+					TotalOSDUsedDataKB:     10737418240,
+					CRUSHMap: models.CRUSHMap{
+						Tunables: models.CRUSHTunables{
+							Profile:                "hammer",
+							MinimumRequiredVersion: "hammer",
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "hammer -> jewel available, ~150 of 1000 PGs (~1.5 TiB) to remap",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "old clients are allowed",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "hammer",
+					NumPGs:                 1000,
+					CRUSHMap: models.CRUSHMap{
+						Tunables: models.CRUSHTunables{
+							Profile:                "hammer",
+							MinimumRequiredVersion: "hammer",
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "hammer",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "legacy tunables",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "reef",
+					NumPGs:                 1000,
+					TotalOSDUsedDataKB:     10737418240,
+					CRUSHMap: models.CRUSHMap{
+						Tunables: models.CRUSHTunables{
+							Profile:                "argonaut",
+							LegacyTunables:         true,
+							MinimumRequiredVersion: "argonaut",
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "argonaut (legacy) -> jewel available, ~346 of 1000 PGs (~3.5 TiB) to remap",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "customized tunables",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "reef",
+					NumPGs:                 1000,
+					TotalOSDUsedDataKB:     10737418240,
+					CRUSHMap: models.CRUSHMap{
+						Tunables: models.CRUSHTunables{
+							Profile:                "unknown",
+							MinimumRequiredVersion: "firefly",
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "firefly -> jewel available, ~193 of 1000 PGs (~1.9 TiB) to remap",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeCRUSHTunables,
+				CurrentValue:       "no OSD releases found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := CRUSHTunables(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
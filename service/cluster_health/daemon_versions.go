@@ -0,0 +1,143 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// daemonUpgradeOrder is the order daemons are upgraded in. Monitors and
+// managers are the same stage since cephadm upgrades managers first while
+// package-based upgrades start with monitors
+//
+// Ref: https://docs.ceph.com/en/latest/cephadm/upgrade/
+var daemonUpgradeOrder = [][]string{{"mgr", "mon"}, {"osd"}, {"mds"}, {"rgw"}}
+
+func DaemonVersions(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if cs.Report.NumDaemonsByVersion == nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+			CurrentValue:       "daemon versions are not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	daemonTypes := []string{}
+	allVersions := map[string]struct{}{}
+	versionsByType := map[string][]string{}
+	for daemonType, versions := range cs.Report.NumDaemonsByVersion {
+		if len(versions) == 0 {
+			continue
+		}
+
+		daemonTypes = append(daemonTypes, daemonType)
+		for v := range versions {
+			versionsByType[daemonType] = append(versionsByType[daemonType], v)
+			allVersions[v] = struct{}{}
+		}
+		slices.SortFunc(versionsByType[daemonType], compareVersions)
+	}
+
+	slices.SortFunc(daemonTypes, func(a, b string) int {
+		ai, bi := upgradeOrderIndex(a), upgradeOrderIndex(b)
+		if ai != bi {
+			return ai - bi
+		}
+		return strings.Compare(a, b)
+	})
+
+	if len(daemonTypes) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+			CurrentValue:       "no daemons found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	if len(allVersions) == 1 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+			CurrentValue:       versionsByType[daemonTypes[0]][0],
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	parts := []string{}
+	for _, daemonType := range daemonTypes {
+		parts = append(parts, daemonType+": "+strings.Join(versionsByType[daemonType], ", "))
+	}
+	value := strings.Join(parts, "; ")
+
+	if violation := upgradeOrderViolation(daemonTypes, versionsByType); violation != "" {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+			CurrentValue:       value + " (" + violation + ")",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+		CurrentValue:       value,
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
+
+// upgradeOrderViolation returns description of the first daemon type
+// running version newer than the oldest one of daemon type which must be
+// upgraded before it
+func upgradeOrderViolation(daemonTypes []string, versionsByType map[string][]string) string {
+	for i, earlier := range daemonTypes {
+		if upgradeOrderIndex(earlier) == len(daemonUpgradeOrder) {
+			continue
+		}
+
+		oldest := versionsByType[earlier][0]
+		for _, later := range daemonTypes[i+1:] {
+			if upgradeOrderIndex(later) == len(daemonUpgradeOrder) || upgradeOrderIndex(later) == upgradeOrderIndex(earlier) {
+				continue
+			}
+
+			newest := versionsByType[later][len(versionsByType[later])-1]
+			if compareVersions(newest, oldest) > 0 {
+				return fmt.Sprintf("%s %s is newer than %s %s", later, newest, earlier, oldest)
+			}
+		}
+	}
+	return ""
+}
+
+// upgradeOrderIndex returns position of the daemon type stage in upgrade
+// order, unknown types are placed after all of the known ones
+func upgradeOrderIndex(daemonType string) int {
+	for idx, stage := range daemonUpgradeOrder {
+		if slices.Contains(stage, daemonType) {
+			return idx
+		}
+	}
+	return len(daemonUpgradeOrder)
+}
+
+// compareVersions compares dot-separated versions like `18.2.2` numerically
+func compareVersions(a, b string) int {
+	ap, bp := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		an, aErr := strconv.Atoi(ap[i])
+		bn, bErr := strconv.Atoi(bp[i])
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(ap[i], bp[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if an != bn {
+			return an - bn
+		}
+	}
+	return len(ap) - len(bp)
+}
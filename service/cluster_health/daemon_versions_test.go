@@ -0,0 +1,118 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestDaemonVersions(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "all daemons run the same version",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumDaemonsByVersion: map[string]map[string]uint16{
+						"mon": {"18.2.2": 5},
+						"mgr": {"18.2.2": 2},
+						"osd": {"18.2.2": 15},
+						"rgw": {},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "18.2.2",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "upgrade in progress",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumDaemonsByVersion: map[string]map[string]uint16{
+						"mon":        {"18.2.10": 5},
+						"mgr":        {"18.2.10": 2},
+						"osd":        {"18.2.2": 10, "18.2.10": 5},
+						"rgw":        {"18.2.2": 2},
+						"rbd-mirror": {"18.2.2": 1},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "mgr: 18.2.10; mon: 18.2.10; osd: 18.2.2, 18.2.10; rgw: 18.2.2; rbd-mirror: 18.2.2",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "managers upgraded before monitors",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumDaemonsByVersion: map[string]map[string]uint16{
+						"mon": {"18.2.2": 5},
+						"mgr": {"19.2.0": 2},
+						"osd": {"18.2.2": 15},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "mgr: 19.2.0; mon: 18.2.2; osd: 18.2.2",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "OSDs newer than mons",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumDaemonsByVersion: map[string]map[string]uint16{
+						"mon": {"18.2.2": 5},
+						"mgr": {"18.2.2": 2},
+						"osd": {"18.2.2": 10, "19.2.0": 5},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "mgr: 18.2.2; mon: 18.2.2; osd: 18.2.2, 19.2.0 (osd 19.2.0 is newer than mgr 18.2.2)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "no daemons",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumDaemonsByVersion: map[string]map[string]uint16{},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "no daemons found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+		{
+			name: "daemon versions are not available",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+				CurrentValue:       "daemon versions are not available",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := DaemonVersions(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
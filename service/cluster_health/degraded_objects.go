@@ -0,0 +1,29 @@
+package cluster_health
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/runityru/cephctl/models"
+)
+
+const degradedObjectsDangerousRatio = 0.1
+
+func DegradedObjects(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	st := models.ClusterHealthIndicatorStatusGood
+	if cs.Status.DegradedRatio >= degradedObjectsDangerousRatio {
+		st = models.ClusterHealthIndicatorStatusDangerous
+	} else if cs.Status.DegradedRatio > 0 {
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeDegradedObjects,
+		CurrentValue:       formatRatio(cs.Status.DegradedRatio),
+		CurrentValueStatus: st,
+	}, nil
+}
+
+func formatRatio(v float64) string {
+	return strconv.FormatFloat(v*100, 'f', 2, 64) + "%"
+}
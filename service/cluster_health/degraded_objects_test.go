@@ -0,0 +1,60 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestDegradedObjects(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "no degraded objects",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDegradedObjects,
+				CurrentValue:       "0.00%",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "some objects are degraded",
+			in: models.ClusterSnapshot{
+				Status: models.ClusterStatus{
+					DegradedRatio: 0.0123,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDegradedObjects,
+				CurrentValue:       "1.23%",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "lots of objects are degraded",
+			in: models.ClusterSnapshot{
+				Status: models.ClusterStatus{
+					DegradedRatio: 0.15,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeDegradedObjects,
+				CurrentValue:       "15.00%",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := DegradedObjects(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
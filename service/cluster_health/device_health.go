@@ -7,7 +7,7 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func DeviceHealth(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func DeviceHealth(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	const (
 		riskLevel      = 0.5
 		dangerousLevel = 0.75
@@ -20,7 +20,7 @@ func DeviceHealth(ctx context.Context, cr models.ClusterReport) (models.ClusterH
 		atDangerousDevs uint16
 	)
 
-	for _, dev := range cr.Devices {
+	for _, dev := range cs.Devices {
 		if len(dev.Daemons) > 0 {
 			if dev.WearLevel > dangerousLevel {
 				atDangerousDevs++
@@ -13,7 +13,7 @@ func TestDeviceHealth(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "All OK",
-			in: models.ClusterReport{
+			in: models.ClusterSnapshot{
 				Devices: []models.Device{
 					{
 						ID:        "1",
@@ -35,7 +35,7 @@ func TestDeviceHealth(t *testing.T) {
 		},
 		{
 			name: "Proper status on multiple conditions (dangerous)",
-			in: models.ClusterReport{
+			in: models.ClusterSnapshot{
 				Devices: []models.Device{
 					{
 						ID:        "0",
@@ -62,7 +62,7 @@ func TestDeviceHealth(t *testing.T) {
 		},
 		{
 			name: "Proper status on multiple conditions (at risk)",
-			in: models.ClusterReport{
+			in: models.ClusterSnapshot{
 				Devices: []models.Device{
 					{
 						ID:        "0",
@@ -89,7 +89,7 @@ func TestDeviceHealth(t *testing.T) {
 		},
 		{
 			name: "Devices without daemons",
-			in: models.ClusterReport{
+			in: models.ClusterSnapshot{
 				Devices: []models.Device{
 					{
 						ID:        "0",
@@ -7,17 +7,17 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func DownPGs(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func DownPGs(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
 
-	downPGs := cr.NumPGsByState["down"]
+	downPGs := cs.Report.NumPGsByState["down"]
 	if downPGs > 0 {
 		st = models.ClusterHealthIndicatorStatusDangerous
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeDownPGs,
-		CurrentValue:       fmt.Sprintf("%d of %d", downPGs, cr.NumPGs),
+		CurrentValue:       fmt.Sprintf("%d of %d", downPGs, cs.Report.NumPGs),
 		CurrentValueStatus: st,
 	}, nil
 }
@@ -13,11 +13,13 @@ func TestDownPGs(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "no down OSDs",
-			in: models.ClusterReport{
-				NumPGs: 10,
-				NumPGsByState: map[string]uint32{
-					"active": 10,
-					"clean":  10,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 10,
+					NumPGsByState: map[string]uint32{
+						"active": 10,
+						"clean":  10,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -28,11 +30,13 @@ func TestDownPGs(t *testing.T) {
 		},
 		{
 			name: "some down OSDs",
-			in: models.ClusterReport{
-				NumPGs: 10,
-				NumPGsByState: map[string]uint32{
-					"active": 3,
-					"down":   5,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 10,
+					NumPGsByState: map[string]uint32{
+						"active": 3,
+						"down":   5,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -0,0 +1,228 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/runityru/cephctl/models"
+)
+
+type failureDomain struct {
+	name    string
+	weights map[int32]float64
+	weight  float64
+}
+
+type placement struct {
+	pool          models.Pool
+	failureDomain string
+	domains       []failureDomain
+	weights       map[int32]float64
+	weight        float64
+}
+
+type failureDomainLoss struct {
+	pool             models.Pool
+	failureDomain    string
+	lost             string
+	domainsLeft      int
+	usageRatio       float64
+	notEnoughDomains bool
+}
+
+// FailureDomainCapacity estimates if every pool could re-replicate its data
+// after the largest failure domain bucket of its CRUSH rule went away.
+// Since per-OSD usage is not a part of the report pools raw usage is
+// distributed among OSDs proportionally to their CRUSH weights.
+func FailureDomainCapacity(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	buckets := make(map[int32]models.CRUSHBucket, len(cs.Report.CRUSHMap.Buckets))
+	for _, b := range cs.Report.CRUSHMap.Buckets {
+		buckets[b.ID] = b
+	}
+
+	rules := make(map[int]models.CRUSHRule, len(cs.Report.CRUSHMap.Rules))
+	for _, r := range cs.Report.CRUSHMap.Rules {
+		rules[r.ID] = r
+	}
+
+	capacity := make(map[int32]uint64, len(cs.Report.OSDDaemons))
+	for _, osd := range cs.Report.OSDDaemons {
+		capacity[int32(osd.ID)] = osd.CapacityBytes
+	}
+
+	placements := []placement{}
+	for _, pool := range cs.Report.Pools {
+		rule, ok := rules[pool.CrushRule]
+		if !ok {
+			continue
+		}
+
+		if _, ok := buckets[rule.Root]; !ok {
+			continue
+		}
+
+		p := placement{
+			pool:          pool,
+			failureDomain: rule.FailureDomain,
+			domains:       failureDomains(buckets, rule.Root, rule.FailureDomain),
+			weights:       map[int32]float64{},
+		}
+		collectOSDWeights(buckets, rule.Root, p.weights)
+		for _, w := range p.weights {
+			p.weight += w
+		}
+
+		if p.weight > 0 && len(p.domains) > 0 {
+			placements = append(placements, p)
+		}
+	}
+
+	if len(placements) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+			CurrentValue:       "no pools with resolvable CRUSH rules",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	var worst *failureDomainLoss
+	for _, p := range placements {
+		loss := simulateFailureDomainLoss(p, placements, capacity)
+		if worst == nil || loss.isWorseThan(*worst) {
+			worst = &loss
+		}
+	}
+
+	st := models.ClusterHealthIndicatorStatusGood
+	value := fmt.Sprintf("%s: %s headroom after losing %s %s",
+		worst.pool.Name,
+		strconv.FormatFloat((float64(cs.Report.FullRatio)-worst.usageRatio)*100, 'f', 2, 64)+"%",
+		worst.failureDomain, worst.lost,
+	)
+
+	switch {
+	case worst.notEnoughDomains:
+		st = models.ClusterHealthIndicatorStatusDangerous
+		value = fmt.Sprintf("%s: %d %s(s) left after losing %s while %d required",
+			worst.pool.Name, worst.domainsLeft, worst.failureDomain, worst.lost, worst.pool.Size)
+	case worst.usageRatio >= float64(cs.Report.FullRatio):
+		st = models.ClusterHealthIndicatorStatusDangerous
+	case worst.usageRatio >= float64(cs.Report.NearfullRatio):
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+		CurrentValue:       value,
+		CurrentValueStatus: st,
+	}, nil
+}
+
+func (l failureDomainLoss) isWorseThan(o failureDomainLoss) bool {
+	if l.notEnoughDomains != o.notEnoughDomains {
+		return l.notEnoughDomains
+	}
+	return l.usageRatio > o.usageRatio
+}
+
+func simulateFailureDomainLoss(p placement, placements []placement, capacity map[int32]uint64) failureDomainLoss {
+	largest := p.domains[0]
+	for _, d := range p.domains[1:] {
+		if d.weight > largest.weight {
+			largest = d
+		}
+	}
+
+	domainsLeft := 0
+	for _, d := range p.domains {
+		if d.name != largest.name && d.weight > 0 {
+			domainsLeft++
+		}
+	}
+
+	loss := failureDomainLoss{
+		pool:             p.pool,
+		failureDomain:    p.failureDomain,
+		lost:             largest.name,
+		domainsLeft:      domainsLeft,
+		notEnoughDomains: domainsLeft < int(p.pool.Size),
+	}
+
+	used := map[int32]float64{}
+	for _, q := range placements {
+		weightLeft := q.weight
+		for osd := range largest.weights {
+			weightLeft -= q.weights[osd]
+		}
+
+		if weightLeft <= 0 {
+			continue
+		}
+
+		for osd, w := range q.weights {
+			if _, ok := largest.weights[osd]; !ok {
+				used[osd] += float64(q.pool.AllocatedBytes) * w / weightLeft
+			}
+		}
+	}
+
+	for osd, w := range p.weights {
+		if _, ok := largest.weights[osd]; ok || w == 0 || capacity[osd] == 0 {
+			continue
+		}
+
+		loss.usageRatio = math.Max(loss.usageRatio, used[osd]/float64(capacity[osd]))
+	}
+
+	return loss
+}
+
+// failureDomains returns buckets of the given type under the root. OSDs are
+// returned as failure domains on their own when the type is `osd`
+func failureDomains(buckets map[int32]models.CRUSHBucket, root int32, typ string) []failureDomain {
+	b, ok := buckets[root]
+	if !ok {
+		return nil
+	}
+
+	if b.Type == typ {
+		d := failureDomain{
+			name:    b.Name,
+			weights: map[int32]float64{},
+		}
+		collectOSDWeights(buckets, b.ID, d.weights)
+		for _, w := range d.weights {
+			d.weight += w
+		}
+		return []failureDomain{d}
+	}
+
+	domains := []failureDomain{}
+	for _, item := range b.Items {
+		if item.ID >= 0 {
+			if typ == "osd" {
+				domains = append(domains, failureDomain{
+					name:    "osd." + strconv.FormatInt(int64(item.ID), 10),
+					weights: map[int32]float64{item.ID: item.Weight},
+					weight:  item.Weight,
+				})
+			}
+			continue
+		}
+
+		domains = append(domains, failureDomains(buckets, item.ID, typ)...)
+	}
+	return domains
+}
+
+func collectOSDWeights(buckets map[int32]models.CRUSHBucket, id int32, weights map[int32]float64) {
+	for _, item := range buckets[id].Items {
+		if item.ID >= 0 {
+			weights[item.ID] += item.Weight
+			continue
+		}
+		collectOSDWeights(buckets, item.ID, weights)
+	}
+}
@@ -0,0 +1,152 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestFailureDomainCapacity(t *testing.T) {
+	const tb = 1_000_000_000_000
+
+	crushMap := func(hosts ...string) models.CRUSHMap {
+		cm := models.CRUSHMap{
+			Buckets: []models.CRUSHBucket{
+				{ID: -1, Name: "default", Type: "root"},
+			},
+			Rules: []models.CRUSHRule{
+				{ID: 0, Name: "replicated_rule", Root: -1, FailureDomain: "host"},
+				{ID: 1, Name: "replicated_osd", Root: -1, FailureDomain: "osd"},
+			},
+		}
+		for i, host := range hosts {
+			id := int32(-2 - i)
+			cm.Buckets[0].Items = append(cm.Buckets[0].Items, models.CRUSHBucketItem{ID: id, Weight: 2})
+			cm.Buckets = append(cm.Buckets, models.CRUSHBucket{
+				ID:   id,
+				Name: host,
+				Type: "host",
+				Items: []models.CRUSHBucketItem{
+					{ID: int32(i * 2), Weight: 1},
+					{ID: int32(i*2 + 1), Weight: 1},
+				},
+			})
+		}
+		return cm
+	}
+
+	osds := func(n int) []models.OSDDaemon {
+		out := []models.OSDDaemon{}
+		for i := 0; i < n; i++ {
+			out = append(out, models.OSDDaemon{ID: uint16(i), CapacityBytes: tb})
+		}
+		return out
+	}
+
+	tcs := []testCase{
+		{
+			name: "enough room left",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					CRUSHMap:   crushMap("nuc01", "nuc02", "nuc03", "nuc04"),
+					OSDDaemons: osds(8),
+					Pools: []models.Pool{
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2, AllocatedBytes: 3 * tb},
+					},
+					NearfullRatio: 0.85,
+					FullRatio:     0.95,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+				CurrentValue:       "volumes: 45.00% headroom after losing host nuc01",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "worst pool is reported",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					CRUSHMap:   crushMap("nuc01", "nuc02", "nuc03", "nuc04"),
+					OSDDaemons: osds(8),
+					Pools: []models.Pool{
+						{Name: "scratch", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2, CrushRule: 1, AllocatedBytes: 2 * tb},
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2, AllocatedBytes: 3.4 * tb},
+					},
+					NearfullRatio: 0.85,
+					FullRatio:     0.95,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+				CurrentValue:       "volumes: 5.00% headroom after losing host nuc01",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "OSDs become full",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					CRUSHMap:   crushMap("nuc01", "nuc02", "nuc03", "nuc04"),
+					OSDDaemons: osds(8),
+					Pools: []models.Pool{
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2, AllocatedBytes: 6 * tb},
+					},
+					NearfullRatio: 0.85,
+					FullRatio:     0.95,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+				CurrentValue:       "volumes: -5.00% headroom after losing host nuc01",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "not enough hosts",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					CRUSHMap:   crushMap("nuc01", "nuc02", "nuc03"),
+					OSDDaemons: osds(6),
+					Pools: []models.Pool{
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2, AllocatedBytes: tb},
+					},
+					NearfullRatio: 0.85,
+					FullRatio:     0.95,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+				CurrentValue:       "volumes: 2 host(s) left after losing nuc01 while 3 required",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "no pools",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					CRUSHMap:   crushMap("nuc01", "nuc02", "nuc03"),
+					OSDDaemons: osds(6),
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeFailureDomainCapacity,
+				CurrentValue:       "no pools with resolvable CRUSH rules",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := FailureDomainCapacity(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
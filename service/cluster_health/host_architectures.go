@@ -0,0 +1,13 @@
+package cluster_health
+
+import (
+	"context"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func HostArchitectures(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	return hostsSkew(models.ClusterHealthIndicatorTypeHostArchitectures, cs.Report.OSDDaemons, func(osd models.OSDDaemon) string {
+		return osd.Architecture
+	}), nil
+}
@@ -0,0 +1,68 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestHostArchitectures(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "single architecture",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", Architecture: "x86_64"},
+						{ID: 1, Hostname: "nuc02", Architecture: "x86_64"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostArchitectures,
+				CurrentValue:       "x86_64",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "mixed architectures",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", Architecture: "x86_64"},
+						{ID: 1, Hostname: "nuc01", Architecture: "x86_64"},
+						{ID: 2, Hostname: "nuc03", Architecture: "x86_64"},
+						{ID: 3, Hostname: "rpi01", Architecture: "aarch64"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostArchitectures,
+				CurrentValue:       "aarch64: rpi01; x86_64: nuc01, nuc03",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostArchitectures,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := HostArchitectures(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
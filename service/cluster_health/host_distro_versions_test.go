@@ -0,0 +1,67 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestHostDistroVersions(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "single distro version",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", Distro: "debian", DistroVersion: "12"},
+						{ID: 1, Hostname: "nuc02", Distro: "debian", DistroVersion: "12"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostDistroVersions,
+				CurrentValue:       "debian 12",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "distro version skew",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", Distro: "debian", DistroVersion: "12"},
+						{ID: 1, Hostname: "nuc02", Distro: "debian", DistroVersion: "11"},
+						{ID: 2, Hostname: "nuc03", Distro: "centos", DistroVersion: "8"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostDistroVersions,
+				CurrentValue:       "centos 8: nuc03; debian 11: nuc02; debian 12: nuc01",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostDistroVersions,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := HostDistroVersions(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
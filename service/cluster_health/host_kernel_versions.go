@@ -0,0 +1,13 @@
+package cluster_health
+
+import (
+	"context"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func HostKernelVersions(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	return hostsSkew(models.ClusterHealthIndicatorTypeHostKernelVersions, cs.Report.OSDDaemons, func(osd models.OSDDaemon) string {
+		return osd.KernelVersion
+	}), nil
+}
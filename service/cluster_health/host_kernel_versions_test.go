@@ -0,0 +1,67 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestHostKernelVersions(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "single kernel version",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", KernelVersion: "6.8.4-2-pve"},
+						{ID: 1, Hostname: "nuc02", KernelVersion: "6.8.4-2-pve"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostKernelVersions,
+				CurrentValue:       "6.8.4-2-pve",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "kernel version skew",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc02", KernelVersion: "6.8.4-2-pve"},
+						{ID: 1, Hostname: "nuc01", KernelVersion: "6.8.4-2-pve"},
+						{ID: 2, Hostname: "nuc03", KernelVersion: "6.5.13-5-pve"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostKernelVersions,
+				CurrentValue:       "6.5.13-5-pve: nuc03; 6.8.4-2-pve: nuc01, nuc02",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostKernelVersions,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := HostKernelVersions(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cluster_health
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func HostSwap(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.OSDDaemons) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+			CurrentValue:       "no OSD metadata found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	// All of the OSDs on the host report the same amount of swap
+	swapByHost := map[string]uint64{}
+	for _, osd := range cs.Report.OSDDaemons {
+		if osd.SwapTotalBytes > 0 {
+			swapByHost[osd.Hostname] = osd.SwapTotalBytes
+		}
+	}
+
+	if len(swapByHost) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+			CurrentValue:       "none",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	hosts := make([]string, 0, len(swapByHost))
+	for hostname := range swapByHost {
+		hosts = append(hosts, hostname)
+	}
+	slices.Sort(hosts)
+
+	offenders := make([]string, 0, len(hosts))
+	for _, hostname := range hosts {
+		offenders = append(offenders, hostname+" ("+formatBytes(swapByHost[hostname])+")")
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+		CurrentValue:       strings.Join(offenders, ", "),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
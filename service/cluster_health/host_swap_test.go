@@ -0,0 +1,68 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestHostSwap(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "no swap",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01"},
+						{ID: 1, Hostname: "nuc02"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "swap enabled on some hosts",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", SwapTotalBytes: 4 << 30},
+						{ID: 1, Hostname: "nuc01", SwapTotalBytes: 4 << 30},
+						{ID: 2, Hostname: "nuc02"},
+						{ID: 3, Hostname: "nuc03", SwapTotalBytes: 512 << 20},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+				CurrentValue:       "nuc01 (4.0 GiB), nuc03 (512.0 MiB)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeHostSwap,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := HostSwap(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cluster_health
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// hostsBy groups hostnames of OSD daemons by the value returned by key
+func hostsBy(osds []models.OSDDaemon, key func(osd models.OSDDaemon) string) map[string][]string {
+	groups := map[string][]string{}
+	for _, osd := range osds {
+		k := key(osd)
+		if !slices.Contains(groups[k], osd.Hostname) {
+			groups[k] = append(groups[k], osd.Hostname)
+		}
+	}
+
+	for _, hosts := range groups {
+		slices.Sort(hosts)
+	}
+	return groups
+}
+
+// sortedOSDs returns the copy of OSD daemons list sorted by ID
+func sortedOSDs(osds []models.OSDDaemon) []models.OSDDaemon {
+	out := slices.Clone(osds)
+	slices.SortFunc(out, func(a, b models.OSDDaemon) int {
+		return int(a.ID) - int(b.ID)
+	})
+	return out
+}
+
+// formatGroups formats groups as `key: item, item; key: item` sorted by key
+func formatGroups(groups map[string][]string) string {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+": "+strings.Join(groups[k], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// hostsSkew reports the value all the hosts share or the hosts grouped by
+// value if there's more than one
+func hostsSkew(indicator models.ClusterHealthIndicatorType, osds []models.OSDDaemon, key func(osd models.OSDDaemon) string) models.ClusterHealthIndicator {
+	if len(osds) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          indicator,
+			CurrentValue:       "no OSD metadata found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}
+	}
+
+	groups := hostsBy(osds, key)
+	if len(groups) == 1 {
+		for k := range groups {
+			return models.ClusterHealthIndicator{
+				Indicator:          indicator,
+				CurrentValue:       k,
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			}
+		}
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          indicator,
+		CurrentValue:       formatGroups(groups),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}
+}
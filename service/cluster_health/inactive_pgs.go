@@ -7,18 +7,18 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func InactivePGs(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func InactivePGs(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
 
-	activePGs := cr.NumPGsByState["active"]
-	inactivePGs := cr.NumPGs - activePGs
+	activePGs := cs.Report.NumPGsByState["active"]
+	inactivePGs := cs.Report.NumPGs - activePGs
 	if inactivePGs > 0 {
 		st = models.ClusterHealthIndicatorStatusDangerous
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeInactivePGs,
-		CurrentValue:       fmt.Sprintf("%d of %d", inactivePGs, cr.NumPGs),
+		CurrentValue:       fmt.Sprintf("%d of %d", inactivePGs, cs.Report.NumPGs),
 		CurrentValueStatus: st,
 	}, nil
 }
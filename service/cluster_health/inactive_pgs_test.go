@@ -13,10 +13,12 @@ func TestInactivePGs(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "no inactive pgs",
-			in: models.ClusterReport{
-				NumPGs: 10,
-				NumPGsByState: map[string]uint32{
-					"active": 10,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 10,
+					NumPGsByState: map[string]uint32{
+						"active": 10,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -27,13 +29,15 @@ func TestInactivePGs(t *testing.T) {
 		},
 		{
 			name: "some inactive pgs",
-			in: models.ClusterReport{
-				NumPGs: 10,
-				NumPGsByState: map[string]uint32{
-					"active":   7,
-					"clean":    7,
-					"degraded": 3,
-					"inactive": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 10,
+					NumPGsByState: map[string]uint32{
+						"active":   7,
+						"clean":    7,
+						"degraded": 3,
+						"inactive": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -6,46 +6,25 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func IPCollision(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
-	frontIPMap := make(map[string]map[string]struct{})
-	backIPMap := make(map[string]map[string]struct{})
-
-	for _, osd := range cr.OSDDaemons {
-		if _, ok := frontIPMap[osd.FrontIP]; !ok {
-			frontIPMap[osd.FrontIP] = map[string]struct{}{
-				osd.Hostname: {},
-			}
-		} else {
-			frontIPMap[osd.FrontIP][osd.Hostname] = struct{}{}
-		}
-
-		if _, ok := backIPMap[osd.BackIP]; !ok {
-			backIPMap[osd.BackIP] = map[string]struct{}{
-				osd.Hostname: {},
+func IPCollision(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	collisions := map[string][]string{}
+	for kind, hostsByIP := range map[string]map[string][]string{
+		"front": hostsBy(cs.Report.OSDDaemons, func(osd models.OSDDaemon) string { return osd.FrontIP }),
+		"back":  hostsBy(cs.Report.OSDDaemons, func(osd models.OSDDaemon) string { return osd.BackIP }),
+	} {
+		for ip, hosts := range hostsByIP {
+			if len(hosts) > 1 {
+				collisions[kind+" "+ip] = hosts
 			}
-		} else {
-			backIPMap[osd.BackIP][osd.Hostname] = struct{}{}
 		}
 	}
 
-	for _, v := range frontIPMap {
-		if len(v) > 1 {
-			return models.ClusterHealthIndicator{
-				Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
-				CurrentValue:       "2 or more hosts have the same front IP",
-				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
-			}, nil
-		}
-	}
-
-	for _, v := range backIPMap {
-		if len(v) > 1 {
-			return models.ClusterHealthIndicator{
-				Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
-				CurrentValue:       "2 or more hosts have the same back IP",
-				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
-			}, nil
-		}
+	if len(collisions) > 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
+			CurrentValue:       formatGroups(collisions),
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+		}, nil
 	}
 
 	return models.ClusterHealthIndicator{
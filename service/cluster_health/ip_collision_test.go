@@ -13,27 +13,29 @@ func TestIPCollision(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "no collisions",
-			in: models.ClusterReport{
-				OSDDaemons: []models.OSDDaemon{
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.2",
-						BackIP:   "192.168.2.2",
-					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.2",
-						BackIP:   "192.168.2.2",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.2",
+							BackIP:   "192.168.2.2",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.2",
+							BackIP:   "192.168.2.2",
+						},
 					},
 				},
 			},
@@ -45,65 +47,108 @@ func TestIPCollision(t *testing.T) {
 		},
 		{
 			name: "front IP collision",
-			in: models.ClusterReport{
-				OSDDaemons: []models.OSDDaemon{
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.2",
-					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.2",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.2",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.2",
+						},
 					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
-				CurrentValue:       "2 or more hosts have the same front IP",
+				CurrentValue:       "front 192.168.1.1: host1, host2",
 				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
 			},
 		},
 		{
 			name: "back IP collision",
-			in: models.ClusterReport{
-				OSDDaemons: []models.OSDDaemon{
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host1",
-						FrontIP:  "192.168.1.1",
-						BackIP:   "192.168.2.1",
-					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.2",
-						BackIP:   "192.168.2.1",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.2",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.2",
+							BackIP:   "192.168.2.1",
+						},
 					},
-					{
-						Hostname: "host2",
-						FrontIP:  "192.168.1.2",
-						BackIP:   "192.168.2.1",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
+				CurrentValue:       "back 192.168.2.1: host1, host2",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "multiple collisions",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{
+							Hostname: "host1",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host2",
+							FrontIP:  "192.168.1.1",
+							BackIP:   "192.168.2.1",
+						},
+						{
+							Hostname: "host3",
+							FrontIP:  "192.168.1.3",
+							BackIP:   "192.168.2.3",
+						},
+						{
+							Hostname: "host4",
+							FrontIP:  "192.168.1.3",
+							BackIP:   "192.168.2.4",
+						},
+						{
+							Hostname: "host5",
+							FrontIP:  "192.168.1.3",
+							BackIP:   "192.168.2.5",
+						},
 					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeIPCollision,
-				CurrentValue:       "2 or more hosts have the same back IP",
+				CurrentValue:       "back 192.168.2.1: host1, host2; front 192.168.1.1: host1, host2; front 192.168.1.3: host3, host4, host5",
 				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
 			},
 		},
@@ -0,0 +1,34 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func Maintenance(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if cs.Maintenance == nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+			CurrentValue:       "none",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	expiresAt := cs.Maintenance.ExpiresAt.UTC().Format(time.RFC3339)
+	if cs.Maintenance.IsExpired(time.Now()) {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+			CurrentValue:       fmt.Sprintf("%s (expired at %s)", cs.Maintenance.Who, expiresAt),
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+		CurrentValue:       fmt.Sprintf("%s (expires at %s)", cs.Maintenance.Who, expiresAt),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestMaintenance(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	expiredAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	tcs := []testCase{
+		{
+			name: "no maintenance",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "maintenance in progress",
+			in: models.ClusterSnapshot{
+				Maintenance: &models.MaintenanceLease{
+					Who:       "nuc01",
+					ExpiresAt: expiresAt,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+				CurrentValue:       "nuc01 (expires at " + expiresAt.Format(time.RFC3339) + ")",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "forgotten maintenance",
+			in: models.ClusterSnapshot{
+				Maintenance: &models.MaintenanceLease{
+					Who:       "osd.3",
+					ExpiresAt: expiredAt,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMaintenance,
+				CurrentValue:       "osd.3 (expired at " + expiredAt.Format(time.RFC3339) + ")",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := Maintenance(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package cluster_health
+
+import (
+	"context"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func MisplacedObjects(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	st := models.ClusterHealthIndicatorStatusGood
+	if cs.Status.MisplacedRatio > 0 {
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeMisplacedObjects,
+		CurrentValue:       formatRatio(cs.Status.MisplacedRatio),
+		CurrentValueStatus: st,
+	}, nil
+}
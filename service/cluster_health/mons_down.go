@@ -0,0 +1,29 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func MonsDown(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if cs.Status.MonsTotal == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeMonsDown,
+			CurrentValue:       "no monitors found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	st := models.ClusterHealthIndicatorStatusGood
+	if cs.Status.MonsDownAmount > 0 {
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeMonsDown,
+		CurrentValue:       fmt.Sprintf("%d of %d", cs.Status.MonsDownAmount, cs.Status.MonsTotal),
+		CurrentValueStatus: st,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestMonsDown(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "all mons are up",
+			in: models.ClusterSnapshot{
+				Status: models.ClusterStatus{
+					MonsTotal:      5,
+					MonsDownAmount: 0,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMonsDown,
+				CurrentValue:       "0 of 5",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "some mons are down",
+			in: models.ClusterSnapshot{
+				Status: models.ClusterStatus{
+					MonsTotal:      5,
+					MonsDownAmount: 2,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMonsDown,
+				CurrentValue:       "2 of 5",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no status data",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeMonsDown,
+				CurrentValue:       "no monitors found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := MonsDown(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -7,11 +7,11 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func MutesAmount(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
-	if len(cr.MutedChecks) > 0 {
+func MutesAmount(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.MutedChecks) > 0 {
 		return models.ClusterHealthIndicator{
 			Indicator:          models.ClusterHealthIndicatorTypeMutesAmount,
-			CurrentValue:       fmt.Sprintf("%d of %d", len(cr.MutedChecks), len(cr.Checks)),
+			CurrentValue:       fmt.Sprintf("%d of %d", len(cs.Report.MutedChecks), len(cs.Report.Checks)),
 			CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
 		}, nil
 	}
@@ -23,16 +23,16 @@ func MutesAmount(ctx context.Context, cr models.ClusterReport) (models.ClusterHe
 	}, nil
 }
 
-func OSDsDown(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func OSDsDown(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
-	numOSDsDown := cr.NumOSDs - cr.NumOSDsUp
+	numOSDsDown := cs.Report.NumOSDs - cs.Report.NumOSDsUp
 	if numOSDsDown > 0 {
 		st = models.ClusterHealthIndicatorStatusAtRisk
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeOSDsDown,
-		CurrentValue:       fmt.Sprintf("%d of %d", numOSDsDown, cr.NumOSDs),
+		CurrentValue:       fmt.Sprintf("%d of %d", numOSDsDown, cs.Report.NumOSDs),
 		CurrentValueStatus: st,
 	}, nil
 }
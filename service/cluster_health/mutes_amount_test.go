@@ -13,8 +13,10 @@ func TestMutesAmount(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "no mutes",
-			in: models.ClusterReport{
-				MutedChecks: []models.ClusterStatusMutedCheck{},
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					MutedChecks: []models.ClusterStatusMutedCheck{},
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeMutesAmount,
@@ -24,11 +26,13 @@ func TestMutesAmount(t *testing.T) {
 		},
 		{
 			name: "mute present",
-			in: models.ClusterReport{
-				MutedChecks: []models.ClusterStatusMutedCheck{
-					{
-						Code:    "SOME_CHECK",
-						Summary: "There a check failed, beware!",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					MutedChecks: []models.ClusterStatusMutedCheck{
+						{
+							Code:    "SOME_CHECK",
+							Summary: "There a check failed, beware!",
+						},
 					},
 				},
 			},
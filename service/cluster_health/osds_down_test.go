@@ -13,9 +13,11 @@ func TestOSDsDown(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "all osds are alive",
-			in: models.ClusterReport{
-				NumOSDs:   10,
-				NumOSDsUp: 10,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:   10,
+					NumOSDsUp: 10,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsDown,
@@ -25,9 +27,11 @@ func TestOSDsDown(t *testing.T) {
 		},
 		{
 			name: "some osds are down",
-			in: models.ClusterReport{
-				NumOSDs:   10,
-				NumOSDsUp: 7,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:   10,
+					NumOSDsUp: 7,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsDown,
@@ -0,0 +1,55 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+// osdFullnessMargin is the distance to nearfull and backfillfull ratios
+// OSD is reported at
+const osdFullnessMargin = 0.05
+
+func OSDsFullness(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if cs.OSDUsage == nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+			CurrentValue:       "OSD usage is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	st := models.ClusterHealthIndicatorStatusGood
+	offenders := []string{}
+	for _, osd := range cs.OSDUsage {
+		if osd.CapacityKB == 0 {
+			continue
+		}
+
+		switch {
+		case osd.Utilization >= float64(cs.Report.BackfillfullRatio)-osdFullnessMargin:
+			st = models.ClusterHealthIndicatorStatusDangerous
+		case osd.Utilization >= float64(cs.Report.NearfullRatio)-osdFullnessMargin:
+			if st == models.ClusterHealthIndicatorStatusGood {
+				st = models.ClusterHealthIndicatorStatusAtRisk
+			}
+		default:
+			continue
+		}
+
+		offenders = append(offenders, fmt.Sprintf("osd.%d (%.2f%%)", osd.ID, osd.Utilization*100))
+	}
+
+	value := "none"
+	if len(offenders) > 0 {
+		value = strings.Join(offenders, ", ")
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+		CurrentValue:       value,
+		CurrentValueStatus: st,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsFullness(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "all OSDs are far from nearfull",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NearfullRatio:     0.85,
+					BackfillfullRatio: 0.9,
+				},
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.51},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.79},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "OSD approaching nearfull",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NearfullRatio:     0.85,
+					BackfillfullRatio: 0.9,
+				},
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.51},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.8123},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+				CurrentValue:       "osd.1 (81.23%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "OSD approaching backfillfull",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NearfullRatio:     0.85,
+					BackfillfullRatio: 0.9,
+				},
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.87},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.82},
+					{ID: 2, Utilization: 0},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+				CurrentValue:       "osd.0 (87.00%), osd.1 (82.00%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "no usage data",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+				CurrentValue:       "OSD usage is not available",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsFullness(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func OSDsHeartbeatAddress(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.OSDDaemons) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+			CurrentValue:       "no OSD metadata found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	offendersByHost := map[string][]string{}
+	for _, osd := range sortedOSDs(cs.Report.OSDDaemons) {
+		mismatches := []string{}
+		if osd.HeartbeatFrontIP != osd.FrontIP {
+			mismatches = append(mismatches, fmt.Sprintf("hb_front %s != front %s", osd.HeartbeatFrontIP, osd.FrontIP))
+		}
+		if osd.HeartbeatBackIP != osd.BackIP {
+			mismatches = append(mismatches, fmt.Sprintf("hb_back %s != back %s", osd.HeartbeatBackIP, osd.BackIP))
+		}
+
+		if len(mismatches) > 0 {
+			offendersByHost[osd.Hostname] = append(offendersByHost[osd.Hostname],
+				fmt.Sprintf("osd.%d (%s)", osd.ID, strings.Join(mismatches, ", ")))
+		}
+	}
+
+	if len(offendersByHost) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+			CurrentValue:       "none",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+		CurrentValue:       formatGroups(offendersByHost),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsHeartbeatAddress(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "heartbeat addresses match",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231", HeartbeatFrontIP: "192.168.1.201", HeartbeatBackIP: "192.168.2.231"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "heartbeat address mismatch",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231", HeartbeatFrontIP: "192.168.1.201", HeartbeatBackIP: "192.168.2.231"},
+						{ID: 1, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231", HeartbeatFrontIP: "10.0.0.1", HeartbeatBackIP: "192.168.2.231"},
+						{ID: 2, Hostname: "nuc02", FrontIP: "192.168.1.202", BackIP: "192.168.2.232", HeartbeatFrontIP: "192.168.1.202", HeartbeatBackIP: "192.168.1.202"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+				CurrentValue:       "nuc01: osd.1 (hb_front 10.0.0.1 != front 192.168.1.201); nuc02: osd.2 (hb_back 192.168.1.202 != back 192.168.2.232)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsHeartbeatAddress,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsHeartbeatAddress(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
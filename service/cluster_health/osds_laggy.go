@@ -0,0 +1,90 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runityru/cephctl/models"
+)
+
+const (
+	// osdLaggyAtRiskProbability is reached after two laggy markdowns in a row
+	// with default mon_osd_laggy_weight of 0.3
+	osdLaggyAtRiskProbability    = 0.5
+	osdLaggyDangerousProbability = 0.8
+
+	// osdFlappingWindow is the period OSD marked down and up again within is
+	// considered flapping right now
+	osdFlappingWindow = time.Hour
+)
+
+func OSDsLaggy(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.OSDStates) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+			CurrentValue:       "no OSD states found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	osds := slices.Clone(cs.Report.OSDStates)
+	slices.SortFunc(osds, func(a, b models.OSDState) int {
+		return int(a.ID) - int(b.ID)
+	})
+
+	st := models.ClusterHealthIndicatorStatusGood
+	offenders := []string{}
+	for _, osd := range osds {
+		isLaggy := osd.LaggyProbability >= osdLaggyAtRiskProbability
+		isFlapping := isOSDFlapping(osd)
+		if !isLaggy && !isFlapping {
+			continue
+		}
+
+		details := []string{}
+		if isLaggy {
+			details = append(details,
+				fmt.Sprintf("laggy probability %.2f%%", osd.LaggyProbability*100),
+				"interval "+osd.LaggyInterval.String(),
+			)
+		}
+
+		if isFlapping {
+			details = append(details, fmt.Sprintf("down at epoch %d and up from epoch %d within last %s", osd.DownAt, osd.UpFrom, osdFlappingWindow))
+		}
+
+		if (isLaggy && isFlapping) || osd.LaggyProbability >= osdLaggyDangerousProbability {
+			st = models.ClusterHealthIndicatorStatusDangerous
+		} else if st != models.ClusterHealthIndicatorStatusDangerous {
+			st = models.ClusterHealthIndicatorStatusAtRisk
+		}
+
+		offenders = append(offenders, fmt.Sprintf("osd.%d (%s)", osd.ID, strings.Join(details, ", ")))
+	}
+
+	if len(offenders) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+			CurrentValue:       "none",
+			CurrentValueStatus: st,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+		CurrentValue:       strings.Join(offenders, ", "),
+		CurrentValueStatus: st,
+	}, nil
+}
+
+// isOSDFlapping reports whether the OSD was marked down and came up again
+// within osdFlappingWindow
+func isOSDFlapping(osd models.OSDState) bool {
+	if !osd.Up || osd.DownAt == 0 || osd.UpFrom <= osd.DownAt || osd.DownStamp.IsZero() {
+		return false
+	}
+	return time.Since(osd.DownStamp) < osdFlappingWindow
+}
@@ -0,0 +1,145 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsLaggy(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "no laggy OSDs",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDStates: []models.OSDState{
+						{ID: 0, Up: true, In: true},
+						{ID: 1, Up: true, In: true, LaggyProbability: 0.3},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "laggy OSD",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDStates: []models.OSDState{
+						{ID: 0, Up: true, In: true},
+						{
+							ID:               1,
+							Up:               true,
+							In:               true,
+							DownStamp:        time.Now().Add(-48 * time.Hour),
+							LaggyProbability: 0.51,
+							LaggyInterval:    4 * time.Second,
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "osd.1 (laggy probability 51.00%, interval 4s)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "highly laggy OSD",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDStates: []models.OSDState{
+						{
+							ID:               4,
+							Up:               true,
+							In:               true,
+							DownStamp:        time.Now().Add(-48 * time.Hour),
+							LaggyProbability: 0.9952524304389954,
+							LaggyInterval:    13 * time.Second,
+						},
+						{
+							ID:               0,
+							Up:               true,
+							In:               true,
+							DownStamp:        time.Now().Add(-48 * time.Hour),
+							LaggyProbability: 0.5823509693145752,
+							LaggyInterval:    39 * time.Second,
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "osd.0 (laggy probability 58.24%, interval 39s), osd.4 (laggy probability 99.53%, interval 13s)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "flapping OSD",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDStates: []models.OSDState{
+						{
+							ID:               7,
+							Up:               true,
+							In:               true,
+							UpFrom:           1205,
+							DownAt:           1203,
+							DownStamp:        time.Now().Add(-10 * time.Minute),
+							LaggyProbability: 0.657,
+							LaggyInterval:    7 * time.Second,
+						},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "osd.7 (laggy probability 65.70%, interval 7s, down at epoch 1203 and up from epoch 1205 within last 1h0m0s)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "OSD went down and up recently",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDStates: []models.OSDState{
+						{ID: 0, Up: true, In: true, UpFrom: 1205, DownAt: 1203, DownStamp: time.Now().Add(-10 * time.Minute)},
+						{ID: 1, Up: true, In: true, UpFrom: 1100, DownAt: 1098, DownStamp: time.Now().Add(-48 * time.Hour)},
+						{ID: 2, Up: false, In: true, UpFrom: 1100, DownAt: 1210, DownStamp: time.Now().Add(-5 * time.Minute)},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "osd.0 (down at epoch 1203 and up from epoch 1205 within last 1h0m0s)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSD states",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsLaggy,
+				CurrentValue:       "no OSD states found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsLaggy(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
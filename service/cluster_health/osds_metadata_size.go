@@ -7,10 +7,10 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func OSDsMetadataSize(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func OSDsMetadataSize(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusUnknown
 
-	metadataSizePercentage := 100.0 / float64(cr.TotalOSDCapacityKB) * float64(cr.TotalOSDUsedMetaKB)
+	metadataSizePercentage := 100.0 / float64(cs.Report.TotalOSDCapacityKB) * float64(cs.Report.TotalOSDUsedMetaKB)
 
 	if metadataSizePercentage > 20.0 {
 		st = models.ClusterHealthIndicatorStatusDangerous
@@ -13,9 +13,11 @@ func TestOSDsMetadataSize(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "metadata size is <7%",
-			in: models.ClusterReport{
-				TotalOSDCapacityKB: 10000,
-				TotalOSDUsedMetaKB: 100,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					TotalOSDCapacityKB: 10000,
+					TotalOSDUsedMetaKB: 100,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsMetadataSize,
@@ -25,9 +27,11 @@ func TestOSDsMetadataSize(t *testing.T) {
 		},
 		{
 			name: "metadata size is >7%",
-			in: models.ClusterReport{
-				TotalOSDCapacityKB: 10000,
-				TotalOSDUsedMetaKB: 1782,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					TotalOSDCapacityKB: 10000,
+					TotalOSDUsedMetaKB: 1782,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsMetadataSize,
@@ -37,9 +41,11 @@ func TestOSDsMetadataSize(t *testing.T) {
 		},
 		{
 			name: "metadata size is >10%",
-			in: models.ClusterReport{
-				TotalOSDCapacityKB: 10000,
-				TotalOSDUsedMetaKB: 2006,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					TotalOSDCapacityKB: 10000,
+					TotalOSDUsedMetaKB: 2006,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsMetadataSize,
@@ -49,7 +55,7 @@ func TestOSDsMetadataSize(t *testing.T) {
 		},
 		{
 			name: "empty structure (division by zero provocation)",
-			in:   models.ClusterReport{},
+			in:   models.ClusterSnapshot{},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsMetadataSize,
 				CurrentValue:       "NaN%",
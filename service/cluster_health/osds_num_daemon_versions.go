@@ -7,8 +7,8 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func OSDsNumDaemonVersions(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
-	numVersions := len(cr.NumOSDsByVersion)
+func OSDsNumDaemonVersions(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	numVersions := len(cs.Report.NumOSDsByVersion)
 
 	st := models.ClusterHealthIndicatorStatusUnknown
 	if numVersions > 2 {
@@ -13,9 +13,11 @@ func TestOSDsNumDaemonVersions(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "single version",
-			in: models.ClusterReport{
-				NumOSDsByVersion: map[string]uint16{
-					"18.2.2": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByVersion: map[string]uint16{
+						"18.2.2": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -26,10 +28,12 @@ func TestOSDsNumDaemonVersions(t *testing.T) {
 		},
 		{
 			name: "two versions",
-			in: models.ClusterReport{
-				NumOSDsByVersion: map[string]uint16{
-					"18.2.1": 1,
-					"18.2.2": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByVersion: map[string]uint16{
+						"18.2.1": 1,
+						"18.2.2": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -40,11 +44,13 @@ func TestOSDsNumDaemonVersions(t *testing.T) {
 		},
 		{
 			name: "three versions",
-			in: models.ClusterReport{
-				NumOSDsByVersion: map[string]uint16{
-					"18.2.0": 1,
-					"18.2.1": 2,
-					"18.2.2": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByVersion: map[string]uint16{
+						"18.2.0": 1,
+						"18.2.1": 2,
+						"18.2.2": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -55,12 +61,14 @@ func TestOSDsNumDaemonVersions(t *testing.T) {
 		},
 		{
 			name: "four versions",
-			in: models.ClusterReport{
-				NumOSDsByVersion: map[string]uint16{
-					"17.2.9": 4,
-					"18.2.0": 1,
-					"18.2.1": 2,
-					"18.2.2": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByVersion: map[string]uint16{
+						"17.2.9": 4,
+						"18.2.0": 1,
+						"18.2.1": 2,
+						"18.2.2": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -71,8 +79,10 @@ func TestOSDsNumDaemonVersions(t *testing.T) {
 		},
 		{
 			name: "no versions",
-			in: models.ClusterReport{
-				NumOSDsByVersion: map[string]uint16{},
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByVersion: map[string]uint16{},
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsNumDaemonVersions,
@@ -0,0 +1,45 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func OSDsNUMAUnknown(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.OSDDaemons) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+			CurrentValue:       "no OSD metadata found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	offendersByHost := map[string][]string{}
+	for _, osd := range sortedOSDs(cs.Report.OSDDaemons) {
+		unknown := append(slices.Clone(osd.NUMAUnknownIfaces), osd.NUMAUnknownDevices...)
+		if len(unknown) == 0 {
+			continue
+		}
+
+		offendersByHost[osd.Hostname] = append(offendersByHost[osd.Hostname],
+			fmt.Sprintf("osd.%d (%s)", osd.ID, strings.Join(unknown, ", ")))
+	}
+
+	if len(offendersByHost) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+			CurrentValue:       "none",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+		CurrentValue:       formatGroups(offendersByHost),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
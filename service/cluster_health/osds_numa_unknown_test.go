@@ -0,0 +1,68 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsNUMAUnknown(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "NUMA affinity is known",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01"},
+						{ID: 1, Hostname: "nuc02"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "some OSDs with unknown NUMA affinity",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 1, Hostname: "nuc01", NUMAUnknownIfaces: []string{"back_iface", "front_iface"}},
+						{ID: 0, Hostname: "nuc01", NUMAUnknownIfaces: []string{"back_iface", "front_iface"}, NUMAUnknownDevices: []string{"sda"}},
+						{ID: 2, Hostname: "nuc02"},
+						{ID: 3, Hostname: "nuc03", NUMAUnknownDevices: []string{"nvme0n1"}},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+				CurrentValue:       "nuc01: osd.0 (back_iface, front_iface, sda), osd.1 (back_iface, front_iface); nuc03: osd.3 (nvme0n1)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsNUMAUnknown,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsNUMAUnknown(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
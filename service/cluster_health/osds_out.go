@@ -7,16 +7,16 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func OSDsOut(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func OSDsOut(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
-	numOSDsOut := cr.NumOSDs - cr.NumOSDsIn
+	numOSDsOut := cs.Report.NumOSDs - cs.Report.NumOSDsIn
 	if numOSDsOut > 0 {
 		st = models.ClusterHealthIndicatorStatusAtRisk
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeOSDsOut,
-		CurrentValue:       fmt.Sprintf("%d of %d", numOSDsOut, cr.NumOSDs),
+		CurrentValue:       fmt.Sprintf("%d of %d", numOSDsOut, cs.Report.NumOSDs),
 		CurrentValueStatus: st,
 	}, nil
 }
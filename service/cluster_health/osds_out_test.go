@@ -13,9 +13,11 @@ func TestOSDsOut(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "all osds are in",
-			in: models.ClusterReport{
-				NumOSDs:   10,
-				NumOSDsIn: 10,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:   10,
+					NumOSDsIn: 10,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsOut,
@@ -25,9 +27,11 @@ func TestOSDsOut(t *testing.T) {
 		},
 		{
 			name: "some osds are out",
-			in: models.ClusterReport{
-				NumOSDs:   10,
-				NumOSDsIn: 7,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:   10,
+					NumOSDsIn: 7,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeOSDsOut,
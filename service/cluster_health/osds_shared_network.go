@@ -0,0 +1,115 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func OSDsSharedNetwork(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.OSDDaemons) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+			CurrentValue:       "no OSD metadata found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	if cs.Config == nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+			CurrentValue:       "configuration is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	networks, err := configNetworks(cs.Config, "public_network")
+	if err != nil {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+			CurrentValue:       err.Error(),
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	offendersByHost := map[string][]string{}
+	for _, osd := range sortedOSDs(cs.Report.OSDDaemons) {
+		if !sameNetwork(networks, osd.FrontIP, osd.BackIP) {
+			continue
+		}
+
+		offendersByHost[osd.Hostname] = append(offendersByHost[osd.Hostname], fmt.Sprintf("osd.%d", osd.ID))
+	}
+
+	if len(offendersByHost) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+			CurrentValue:       "none",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+		CurrentValue:       formatGroups(offendersByHost),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+	}, nil
+}
+
+// configNetworks returns the networks set for OSDs or globally, the value
+// is comma-separated list of subnets
+func configNetworks(cfg models.CephConfig, key string) ([]netip.Prefix, error) {
+	for _, target := range []string{"osd", "global"} {
+		v, ok := cfg[target][key]
+		if !ok {
+			continue
+		}
+
+		networks := []netip.Prefix{}
+		for _, s := range strings.Split(v, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+
+			n, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing %s value", key)
+			}
+			networks = append(networks, n)
+		}
+		return networks, nil
+	}
+	return nil, nil
+}
+
+// sameNetwork reports whether both of the front and back addresses are the
+// same or the back address belongs to the public network the front address
+// is in
+func sameNetwork(publicNetworks []netip.Prefix, front, back string) bool {
+	if front == back {
+		return true
+	}
+
+	frontAddr, err := netip.ParseAddr(front)
+	if err != nil {
+		return false
+	}
+
+	backAddr, err := netip.ParseAddr(back)
+	if err != nil {
+		return false
+	}
+
+	for _, n := range publicNetworks {
+		if n.Contains(frontAddr) && n.Contains(backAddr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsSharedNetwork(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "separate networks",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231"},
+						{ID: 1, Hostname: "nuc02", FrontIP: "192.168.1.202", BackIP: "192.168.2.232"},
+					},
+				},
+				Config: models.CephConfig{
+					"global": {
+						"public_network":  "192.168.1.0/24",
+						"cluster_network": "192.168.2.0/24",
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "same addresses",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 1, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.1.201"},
+						{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.1.201"},
+						{ID: 2, Hostname: "nuc02", FrontIP: "192.168.1.202", BackIP: "192.168.2.232"},
+					},
+				},
+				Config: models.CephConfig{},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "nuc01: osd.0, osd.1",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "addresses within the same network",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231"},
+						{ID: 1, Hostname: "nuc02", FrontIP: "10.0.1.2", BackIP: "10.0.2.2"},
+					},
+				},
+				Config: models.CephConfig{
+					"global": {
+						"public_network": "192.168.1.0/24",
+					},
+					"osd": {
+						"public_network":  "10.0.0.0/16",
+						"cluster_network": "192.168.2.0/24, 10.0.0.0/8",
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "nuc02: osd.1",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "wide cluster network",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "10.0.1.1", BackIP: "10.0.2.1"},
+					},
+				},
+				Config: models.CephConfig{
+					"global": {
+						"public_network":  "10.0.1.0/24",
+						"cluster_network": "10.0.0.0/8",
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "malformed public network",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "10.0.1.1", BackIP: "10.0.2.1"},
+					},
+				},
+				Config: models.CephConfig{
+					"global": {
+						"public_network": "10.0.1.0",
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       `error parsing public_network value: netip.ParsePrefix("10.0.1.0"): no '/'`,
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+		{
+			name: "configuration is not available",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					OSDDaemons: []models.OSDDaemon{
+						{ID: 0, Hostname: "nuc01", FrontIP: "10.0.1.1", BackIP: "10.0.2.1"},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "configuration is not available",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+				CurrentValue:       "no OSD metadata found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsSharedNetwork(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func OSDsUtilizationSpread(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	var minOSD, maxOSD *models.OSDUsage
+	for i, osd := range cs.OSDUsage {
+		if osd.CapacityKB == 0 || osd.Reweight == 0 {
+			continue
+		}
+
+		if minOSD == nil || osd.Utilization < minOSD.Utilization {
+			minOSD = &cs.OSDUsage[i]
+		}
+
+		if maxOSD == nil || osd.Utilization > maxOSD.Utilization {
+			maxOSD = &cs.OSDUsage[i]
+		}
+	}
+
+	if minOSD == nil || minOSD == maxOSD {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+			CurrentValue:       "not enough OSDs with usage data",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	spread := (maxOSD.Utilization - minOSD.Utilization) * 100
+
+	st := models.ClusterHealthIndicatorStatusGood
+	if spread > 20.0 {
+		st = models.ClusterHealthIndicatorStatusDangerous
+	} else if spread > 10.0 {
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator: models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+		CurrentValue: fmt.Sprintf("%.2f%% (min osd.%d: %.2f%%, max osd.%d: %.2f%%)",
+			spread, minOSD.ID, minOSD.Utilization*100, maxOSD.ID, maxOSD.Utilization*100),
+		CurrentValueStatus: st,
+	}, nil
+}
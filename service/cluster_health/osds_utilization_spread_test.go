@@ -0,0 +1,93 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsUtilizationSpread(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "balanced OSDs",
+			in: models.ClusterSnapshot{
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.51, Reweight: 1},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.53, Reweight: 1},
+					{ID: 2, CapacityKB: 1000, Utilization: 0.52, Reweight: 1},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+				CurrentValue:       "2.00% (min osd.0: 51.00%, max osd.1: 53.00%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "drained and down OSDs are ignored",
+			in: models.ClusterSnapshot{
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.51, Reweight: 1},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.53, Reweight: 1},
+					{ID: 2, CapacityKB: 1000, Utilization: 0.02, Reweight: 0},
+					{ID: 3},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+				CurrentValue:       "2.00% (min osd.0: 51.00%, max osd.1: 53.00%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "imbalanced OSDs",
+			in: models.ClusterSnapshot{
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.45, Reweight: 1},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.60, Reweight: 1},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+				CurrentValue:       "15.00% (min osd.0: 45.00%, max osd.1: 60.00%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "heavily imbalanced OSDs",
+			in: models.ClusterSnapshot{
+				OSDUsage: []models.OSDUsage{
+					{ID: 0, CapacityKB: 1000, Utilization: 0.75, Reweight: 1},
+					{ID: 1, CapacityKB: 1000, Utilization: 0.40, Reweight: 1},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+				CurrentValue:       "35.00% (min osd.1: 40.00%, max osd.0: 75.00%)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+		{
+			name: "no usage data",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsUtilizationSpread,
+				CurrentValue:       "not enough OSDs with usage data",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsUtilizationSpread(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func OSDsWithoutClusterAddress(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	st := models.ClusterHealthIndicatorStatusGood
+	if cs.Report.NumOSDsWithoutClusterAddress > 0 {
+		st = models.ClusterHealthIndicatorStatusAtRisk
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeOSDsWithoutClusterAddress,
+		CurrentValue:       fmt.Sprintf("%d of %d", cs.Report.NumOSDsWithoutClusterAddress, cs.Report.NumOSDs),
+		CurrentValueStatus: st,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestOSDsWithoutClusterAddress(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "all OSDs have cluster address",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:                      15,
+					NumOSDsWithoutClusterAddress: 0,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsWithoutClusterAddress,
+				CurrentValue:       "0 of 15",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "some OSDs have no cluster address",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDs:                      15,
+					NumOSDsWithoutClusterAddress: 2,
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeOSDsWithoutClusterAddress,
+				CurrentValue:       "2 of 15",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := OSDsWithoutClusterAddress(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func PoolRedundancy(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	st := models.ClusterHealthIndicatorStatusGood
+	offenders := []string{}
+	for _, pool := range cs.Report.Pools {
+		poolStatus := models.ClusterHealthIndicatorStatusGood
+		desc := fmt.Sprintf("%s (size=%d, min_size=%d)", pool.Name, pool.Size, pool.MinSize)
+
+		switch pool.Type {
+		case models.PoolTypeReplicated:
+			if pool.MinSize < 2 {
+				poolStatus = models.ClusterHealthIndicatorStatusDangerous
+			} else if pool.Size < 3 {
+				poolStatus = models.ClusterHealthIndicatorStatusAtRisk
+			}
+
+		case models.PoolTypeErasure:
+			desc = fmt.Sprintf("%s (k=%d, m=%d, min_size=%d)", pool.Name, pool.ErasureCodeK, pool.ErasureCodeM, pool.MinSize)
+			if pool.MinSize <= pool.ErasureCodeK {
+				poolStatus = models.ClusterHealthIndicatorStatusDangerous
+			} else if pool.MinSize > pool.ErasureCodeK+1 {
+				poolStatus = models.ClusterHealthIndicatorStatusAtRisk
+			}
+		}
+
+		if poolStatus == models.ClusterHealthIndicatorStatusGood {
+			continue
+		}
+
+		offenders = append(offenders, desc)
+		if poolStatus == models.ClusterHealthIndicatorStatusDangerous {
+			st = poolStatus
+		} else if st == models.ClusterHealthIndicatorStatusGood {
+			st = poolStatus
+		}
+	}
+
+	value := "none"
+	if len(offenders) > 0 {
+		value = strings.Join(offenders, ", ")
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypePoolRedundancy,
+		CurrentValue:       value,
+		CurrentValueStatus: st,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestPoolRedundancy(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "all pools are fine",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					Pools: []models.Pool{
+						{Name: ".mgr", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2},
+						{Name: "volumes-ec", Type: models.PoolTypeErasure, Size: 6, MinSize: 5, ErasureCodeK: 4, ErasureCodeM: 2},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypePoolRedundancy,
+				CurrentValue:       "none",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "size 2 pool",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					Pools: []models.Pool{
+						{Name: ".mgr", Type: models.PoolTypeReplicated, Size: 3, MinSize: 2},
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 2, MinSize: 2},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypePoolRedundancy,
+				CurrentValue:       "volumes (size=2, min_size=2)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "erasure coded pool with min_size above k+1",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					Pools: []models.Pool{
+						{Name: "volumes-ec", Type: models.PoolTypeErasure, Size: 7, MinSize: 7, ErasureCodeK: 4, ErasureCodeM: 3},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypePoolRedundancy,
+				CurrentValue:       "volumes-ec (k=4, m=3, min_size=7)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "pools without redundancy",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					Pools: []models.Pool{
+						{Name: "volumes", Type: models.PoolTypeReplicated, Size: 2, MinSize: 2},
+						{Name: "scratch", Type: models.PoolTypeReplicated, Size: 2, MinSize: 1},
+						{Name: "volumes-ec", Type: models.PoolTypeErasure, Size: 5, MinSize: 4, ErasureCodeK: 4, ErasureCodeM: 1},
+					},
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypePoolRedundancy,
+				CurrentValue:       "volumes (size=2, min_size=2), scratch (size=2, min_size=1), volumes-ec (k=4, m=1, min_size=4)",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusDangerous,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := PoolRedundancy(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -7,15 +7,15 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func Quorum(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func Quorum(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
-	if cr.NumMonsInQuorum < cr.NumMons {
+	if cs.Report.NumMonsInQuorum < cs.Report.NumMons {
 		st = models.ClusterHealthIndicatorStatusAtRisk
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeQuorum,
-		CurrentValue:       fmt.Sprintf("%d of %d", cr.NumMonsInQuorum, cr.NumMons),
+		CurrentValue:       fmt.Sprintf("%d of %d", cs.Report.NumMonsInQuorum, cs.Report.NumMons),
 		CurrentValueStatus: st,
 	}, nil
 }
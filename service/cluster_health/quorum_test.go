@@ -13,9 +13,11 @@ func TestQuorum(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "all in quorum",
-			in: models.ClusterReport{
-				NumMons:         5,
-				NumMonsInQuorum: 5,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumMons:         5,
+					NumMonsInQuorum: 5,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeQuorum,
@@ -25,9 +27,11 @@ func TestQuorum(t *testing.T) {
 		},
 		{
 			name: "some out of quorum",
-			in: models.ClusterReport{
-				NumMons:         5,
-				NumMonsInQuorum: 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumMons:         5,
+					NumMonsInQuorum: 3,
+				},
 			},
 			expOut: models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeQuorum,
@@ -0,0 +1,62 @@
+package cluster_health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func ReleaseFlags(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+	if len(cs.Report.NumOSDsByRelease) == 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+			CurrentValue:       "no OSD releases found",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		}, nil
+	}
+
+	lowestRelease := ""
+	for release := range cs.Report.NumOSDsByRelease {
+		if lowestRelease == "" || models.CompareCephReleases(release, lowestRelease) < 0 {
+			lowestRelease = release
+		}
+	}
+
+	lags := []string{}
+	for _, flag := range []struct {
+		name  string
+		value string
+	}{
+		{"require_osd_release", cs.Report.RequireOSDRelease},
+		{"require_min_compat_client", cs.Report.RequireMinCompatClient},
+	} {
+		if !slices.Contains(models.CephReleases, flag.value) {
+			return models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       fmt.Sprintf("unexpected %s value `%s`", flag.name, flag.value),
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			}, nil
+		}
+
+		if models.CompareCephReleases(flag.value, lowestRelease) < 0 {
+			lags = append(lags, fmt.Sprintf("%s=%s while OSDs run %s at least", flag.name, flag.value, lowestRelease))
+		}
+	}
+
+	if len(lags) > 0 {
+		return models.ClusterHealthIndicator{
+			Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+			CurrentValue:       strings.Join(lags, "; "),
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+		}, nil
+	}
+
+	return models.ClusterHealthIndicator{
+		Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+		CurrentValue:       fmt.Sprintf("require_osd_release=%s, require_min_compat_client=%s", cs.Report.RequireOSDRelease, cs.Report.RequireMinCompatClient),
+		CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package cluster_health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runityru/cephctl/models"
+)
+
+func TestReleaseFlags(t *testing.T) {
+	tcs := []testCase{
+		{
+			name: "flags are raised",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireOSDRelease:      "reef",
+					RequireMinCompatClient: "reef",
+					MinCompatClient:        "luminous",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "require_osd_release=reef, require_min_compat_client=reef",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "upgrade in progress",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"quincy": 10, "reef": 5},
+					RequireOSDRelease:      "quincy",
+					RequireMinCompatClient: "quincy",
+					MinCompatClient:        "luminous",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "require_osd_release=quincy, require_min_compat_client=quincy",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusGood,
+			},
+		},
+		{
+			name: "require_osd_release is not raised after upgrade",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireOSDRelease:      "quincy",
+					RequireMinCompatClient: "reef",
+					MinCompatClient:        "luminous",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "require_osd_release=quincy while OSDs run reef at least",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "require_min_compat_client is not raised after upgrade",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireOSDRelease:      "reef",
+					RequireMinCompatClient: "luminous",
+					MinCompatClient:        "luminous",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "require_min_compat_client=luminous while OSDs run reef at least",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "both flags lag",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireOSDRelease:      "pacific",
+					RequireMinCompatClient: "jewel",
+					MinCompatClient:        "luminous",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "require_osd_release=pacific while OSDs run reef at least; require_min_compat_client=jewel while OSDs run reef at least",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusAtRisk,
+			},
+		},
+		{
+			name: "require_osd_release is not set",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireMinCompatClient: "reef",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "unexpected require_osd_release value ``",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+		{
+			name: "unexpected require_min_compat_client value",
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumOSDsByRelease:       map[string]uint16{"reef": 15},
+					RequireOSDRelease:      "reef",
+					RequireMinCompatClient: "bobcat",
+				},
+			},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "unexpected require_min_compat_client value `bobcat`",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+		{
+			name: "no OSDs",
+			in:   models.ClusterSnapshot{},
+			expOut: models.ClusterHealthIndicator{
+				Indicator:          models.ClusterHealthIndicatorTypeReleaseFlags,
+				CurrentValue:       "no OSD releases found",
+				CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			i, err := ReleaseFlags(context.Background(), tc.in)
+			r.NoError(err)
+			r.Equal(tc.expOut, i)
+		})
+	}
+}
@@ -7,18 +7,18 @@ import (
 	"github.com/runityru/cephctl/models"
 )
 
-func UncleanPGs(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+func UncleanPGs(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
 	st := models.ClusterHealthIndicatorStatusGood
 
-	cleanPGs := cr.NumPGsByState["clean"]
-	uncleanPGs := cr.NumPGs - cleanPGs
+	cleanPGs := cs.Report.NumPGsByState["clean"]
+	uncleanPGs := cs.Report.NumPGs - cleanPGs
 	if uncleanPGs > 0 {
 		st = models.ClusterHealthIndicatorStatusAtRisk
 	}
 
 	return models.ClusterHealthIndicator{
 		Indicator:          models.ClusterHealthIndicatorTypeUncleanPGs,
-		CurrentValue:       fmt.Sprintf("%d of %d", uncleanPGs, cr.NumPGs),
+		CurrentValue:       fmt.Sprintf("%d of %d", uncleanPGs, cs.Report.NumPGs),
 		CurrentValueStatus: st,
 	}, nil
 }
@@ -13,10 +13,12 @@ func TestUncleanPGs(t *testing.T) {
 	tcs := []testCase{
 		{
 			name: "no unclean pgs",
-			in: models.ClusterReport{
-				NumPGs: 10,
-				NumPGsByState: map[string]uint32{
-					"clean": 10,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 10,
+					NumPGsByState: map[string]uint32{
+						"clean": 10,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -27,12 +29,14 @@ func TestUncleanPGs(t *testing.T) {
 		},
 		{
 			name: "some unclean pgs",
-			in: models.ClusterReport{
-				NumPGs: 13,
-				NumPGsByState: map[string]uint32{
-					"clean":    10,
-					"active":   13,
-					"degraded": 3,
+			in: models.ClusterSnapshot{
+				Report: models.ClusterReport{
+					NumPGs: 13,
+					NumPGsByState: map[string]uint32{
+						"clean":    10,
+						"active":   13,
+						"degraded": 3,
+					},
 				},
 			},
 			expOut: models.ClusterHealthIndicator{
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/runityru/cephctl/models"
+	clusterHealth "github.com/runityru/cephctl/service/cluster_health"
+)
+
+var (
+	ErrMaintenanceInProgress = errors.New("maintenance is already in progress")
+	ErrNoMaintenance         = errors.New("no maintenance in progress")
+	ErrPreconditionsFailed   = errors.New("maintenance preconditions failed")
+	ErrUnknownTarget         = errors.New("unknown host or OSD")
+)
+
+// maintenanceGroupFlags are set for the CRUSH node or OSD under maintenance
+var maintenanceGroupFlags = []string{"noout"}
+
+// maintenanceClusterFlags are set cluster-wide since Ceph doesn't support
+// them for CRUSH nodes or OSDs
+var maintenanceClusterFlags = []string{"norebalance"}
+
+// StartMaintenance starts maintenance of the host (CRUSH node name) or
+// the OSD (i.e. osd.3): checks are run as preconditions and each of them
+// must be good, then the lease is persisted and maintenance flags are set.
+// The flags which are already set are kept out of the lease so they're
+// left as is when maintenance is ended.
+func (s *service) StartMaintenance(ctx context.Context, who string, ttl time.Duration, checks []clusterHealth.ClusterHealthCheck) (models.MaintenanceLease, error) {
+	current, err := s.c.GetMaintenanceLease(ctx)
+	if err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error retrieving maintenance lease")
+	}
+
+	if current != nil {
+		return models.MaintenanceLease{}, errors.Wrapf(ErrMaintenanceInProgress, "`%s` since %s", current.Who, current.StartedAt.UTC().Format(time.RFC3339))
+	}
+
+	cr, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error collecting cluster report")
+	}
+
+	if _, err := resolveOSDs(cr, who); err != nil {
+		return models.MaintenanceLease{}, err
+	}
+	isOSD := strings.HasPrefix(who, "osd.")
+
+	indicators, err := runChecks(ctx, models.ClusterSnapshot{Report: cr}, checks)
+	if err != nil {
+		return models.MaintenanceLease{}, err
+	}
+
+	failed := []string{}
+	for _, indicator := range indicators {
+		if indicator.CurrentValueStatus != models.ClusterHealthIndicatorStatusGood {
+			failed = append(failed, fmt.Sprintf("%s = %s", indicator.Indicator, indicator.CurrentValue))
+		}
+	}
+
+	if len(failed) > 0 {
+		return models.MaintenanceLease{}, errors.Wrap(ErrPreconditionsFailed, strings.Join(failed, "; "))
+	}
+
+	groupFlags := map[string][]string{}
+	for _, flag := range maintenanceGroupFlags {
+		var set []string
+		if isOSD {
+			set = cr.OSDFlags.OSDs[who]
+		} else {
+			set = cr.OSDFlags.CRUSHNodes[who]
+		}
+
+		if !slices.Contains(set, flag) {
+			groupFlags[who] = append(groupFlags[who], flag)
+		}
+	}
+
+	now := time.Now()
+	lease := models.MaintenanceLease{
+		Who:       who,
+		StartedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	for _, flag := range maintenanceClusterFlags {
+		if !slices.Contains(cr.OSDFlags.Cluster, flag) {
+			lease.Flags.Cluster = append(lease.Flags.Cluster, flag)
+		}
+	}
+
+	if len(groupFlags) > 0 {
+		if isOSD {
+			lease.Flags.OSDs = groupFlags
+		} else {
+			lease.Flags.CRUSHNodes = groupFlags
+		}
+	}
+
+	// Lease is persisted before any flag is set so the flags could be
+	// restored by ending maintenance whatever happens next
+	if err := s.c.SetMaintenanceLease(ctx, lease); err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error persisting maintenance lease")
+	}
+
+	applied := []models.CephOSDFlagsDifference{}
+	err = forEachFlag(lease.Flags, func(flag, who string) error {
+		if err := s.c.SetOSDFlag(ctx, flag, who); err != nil {
+			return err
+		}
+
+		applied = append(applied, models.CephOSDFlagsDifference{
+			Kind: models.CephOSDFlagsDifferenceKindSet,
+			Who:  who,
+			Flag: flag,
+		})
+		return nil
+	})
+	if err != nil {
+		log.Warnf("error setting maintenance flags, rolling back: %s", err)
+
+		for _, change := range applied {
+			if rbErr := s.c.UnsetOSDFlag(ctx, change.Flag, change.Who); rbErr != nil {
+				// Lease is kept to restore the rest of flags by ending maintenance
+				log.Errorf("error rolling back maintenance flags: %s", rbErr)
+				return models.MaintenanceLease{}, errors.Wrap(err, "error setting maintenance flags")
+			}
+		}
+
+		if rbErr := s.c.RemoveMaintenanceLease(ctx); rbErr != nil {
+			log.Errorf("error removing maintenance lease: %s", rbErr)
+		}
+
+		return models.MaintenanceLease{}, errors.Wrap(err, "error setting maintenance flags")
+	}
+
+	return lease, nil
+}
+
+// EndMaintenance unsets the flags set by StartMaintenance and removes the
+// lease
+func (s *service) EndMaintenance(ctx context.Context) (models.MaintenanceLease, error) {
+	lease, err := s.c.GetMaintenanceLease(ctx)
+	if err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error retrieving maintenance lease")
+	}
+
+	if lease == nil {
+		return models.MaintenanceLease{}, ErrNoMaintenance
+	}
+
+	if err := s.restoreFlags(ctx, lease.Flags); err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error restoring flags")
+	}
+
+	if err := s.c.RemoveMaintenanceLease(ctx); err != nil {
+		return models.MaintenanceLease{}, errors.Wrap(err, "error removing maintenance lease")
+	}
+
+	return *lease, nil
+}
+
+// WaitForCleanPGs polls cluster report until all of the PGs are
+// active+clean or context is done
+func (s *service) WaitForCleanPGs(ctx context.Context, interval time.Duration) error {
+	for {
+		cr, err := s.c.ClusterReport(ctx)
+		if err != nil {
+			return errors.Wrap(err, "error collecting cluster report")
+		}
+
+		active := cr.NumPGsByState["active"]
+		clean := cr.NumPGsByState["clean"]
+		if active == cr.NumPGs && clean == cr.NumPGs {
+			return nil
+		}
+
+		log.Infof("waiting for PGs to become active+clean: %d active, %d clean of %d", active, clean, cr.NumPGs)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "PGs are not active+clean")
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *service) restoreFlags(ctx context.Context, flags models.CephOSDFlags) error {
+	return forEachFlag(flags, func(flag, who string) error {
+		return s.c.UnsetOSDFlag(ctx, flag, who)
+	})
+}
+
+// forEachFlag calls fn for group flags first and cluster-wide ones then
+func forEachFlag(flags models.CephOSDFlags, fn func(flag, who string) error) error {
+	for _, group := range []map[string][]string{flags.OSDs, flags.CRUSHNodes} {
+		for who, whoFlags := range group {
+			for _, flag := range whoFlags {
+				if err := fn(flag, who); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, flag := range flags.Cluster {
+		if err := fn(flag, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveOSDs returns IDs of OSDs running on the host or ID of the OSD
+// itself if who is OSD name (i.e. osd.3)
+func resolveOSDs(cr models.ClusterReport, who string) ([]uint16, error) {
+	ids := []uint16{}
+	for _, osd := range cr.OSDDaemons {
+		if osd.Hostname == who || fmt.Sprintf("osd.%d", osd.ID) == who {
+			ids = append(ids, osd.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.Wrapf(ErrUnknownTarget, "`%s`", who)
+	}
+
+	slices.Sort(ids)
+	return ids, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/runityru/cephctl/models"
+	clusterHeath "github.com/runityru/cephctl/service/cluster_health"
+)
+
+func (s *serviceTestSuite) TestStartMaintenanceHost() {
+	call1 := s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	call2 := s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "nuc01"},
+			{ID: 1, Hostname: "nuc02"},
+		},
+		OSDFlags: models.CephOSDFlags{
+			Cluster: []string{"noscrub"},
+		},
+	}, nil).NotBefore(call1).Once()
+
+	expFlags := models.CephOSDFlags{
+		Cluster: []string{"norebalance"},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout"},
+		},
+	}
+
+	call3 := s.cephMock.On("SetMaintenanceLease", mock.MatchedBy(func(l models.MaintenanceLease) bool {
+		return l.Who == "nuc01" &&
+			l.ExpiresAt.Sub(l.StartedAt) == 4*time.Hour &&
+			reflect.DeepEqual(expFlags, l.Flags)
+	})).Return(nil).NotBefore(call2).Once()
+	call4 := s.cephMock.On("SetOSDFlag", "noout", "nuc01").Return(nil).NotBefore(call3).Once()
+	s.cephMock.On("SetOSDFlag", "norebalance", "").Return(nil).NotBefore(call4).Once()
+
+	lease, err := s.svc.StartMaintenance(s.ctx, "nuc01", 4*time.Hour, []clusterHeath.ClusterHealthCheck{
+		clusterHeath.Quorum,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("nuc01", lease.Who)
+	s.Require().Equal(expFlags, lease.Flags)
+}
+
+func (s *serviceTestSuite) TestStartMaintenanceOSDFlagsAlreadySet() {
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 3, Hostname: "nuc01"},
+		},
+		OSDFlags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			OSDs: map[string][]string{
+				"osd.3": {"noout"},
+			},
+		},
+	}, nil).Once()
+	s.cephMock.On("SetMaintenanceLease", mock.MatchedBy(func(l models.MaintenanceLease) bool {
+		return l.Who == "osd.3" && reflect.DeepEqual(models.CephOSDFlags{}, l.Flags)
+	})).Return(nil).Once()
+
+	lease, err := s.svc.StartMaintenance(s.ctx, "osd.3", time.Hour, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephOSDFlags{}, lease.Flags)
+}
+
+func (s *serviceTestSuite) TestStartMaintenanceInProgress() {
+	s.cephMock.On("GetMaintenanceLease").Return(&models.MaintenanceLease{
+		Who:       "nuc02",
+		StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, nil).Once()
+
+	_, err := s.svc.StartMaintenance(s.ctx, "nuc01", time.Hour, nil)
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrMaintenanceInProgress)
+	s.Require().Equal("`nuc02` since 2024-05-01T10:00:00Z: maintenance is already in progress", err.Error())
+}
+
+func (s *serviceTestSuite) TestStartMaintenanceUnknownTarget() {
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "nuc01"},
+		},
+	}, nil).Once()
+
+	_, err := s.svc.StartMaintenance(s.ctx, "osd.5", time.Hour, nil)
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrUnknownTarget)
+}
+
+func (s *serviceTestSuite) TestStartMaintenancePreconditionsFailed() {
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumMons:         3,
+		NumMonsInQuorum: 2,
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "nuc01"},
+		},
+	}, nil).Once()
+
+	_, err := s.svc.StartMaintenance(s.ctx, "nuc01", time.Hour, []clusterHeath.ClusterHealthCheck{
+		clusterHeath.Quorum,
+	})
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrPreconditionsFailed)
+	s.Require().Equal("QUORUM = 2 of 3: maintenance preconditions failed", err.Error())
+}
+
+func (s *serviceTestSuite) TestStartMaintenanceRollback() {
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "nuc01"},
+		},
+	}, nil).Once()
+	call1 := s.cephMock.On("SetMaintenanceLease", mock.Anything).Return(nil).Once()
+	call2 := s.cephMock.On("SetOSDFlag", "noout", "nuc01").Return(nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("SetOSDFlag", "norebalance", "").Return(errors.New("test error")).NotBefore(call2).Once()
+	call4 := s.cephMock.On("UnsetOSDFlag", "noout", "nuc01").Return(nil).NotBefore(call3).Once()
+	s.cephMock.On("RemoveMaintenanceLease").Return(nil).NotBefore(call4).Once()
+
+	_, err := s.svc.StartMaintenance(s.ctx, "nuc01", time.Hour, nil)
+	s.Require().Error(err)
+	s.Require().Equal("error setting maintenance flags: test error", err.Error())
+}
+
+func (s *serviceTestSuite) TestEndMaintenance() {
+	lease := &models.MaintenanceLease{
+		Who: "nuc01",
+		Flags: models.CephOSDFlags{
+			Cluster: []string{"norebalance"},
+			CRUSHNodes: map[string][]string{
+				"nuc01": {"noout"},
+			},
+		},
+	}
+
+	call1 := s.cephMock.On("GetMaintenanceLease").Return(lease, nil).Once()
+	call2 := s.cephMock.On("UnsetOSDFlag", "noout", "nuc01").Return(nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("UnsetOSDFlag", "norebalance", "").Return(nil).NotBefore(call2).Once()
+	s.cephMock.On("RemoveMaintenanceLease").Return(nil).NotBefore(call3).Once()
+
+	out, err := s.svc.EndMaintenance(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(*lease, out)
+}
+
+func (s *serviceTestSuite) TestEndMaintenanceNoLease() {
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+
+	_, err := s.svc.EndMaintenance(s.ctx)
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrNoMaintenance)
+}
+
+func (s *serviceTestSuite) TestWaitForCleanPGs() {
+	call1 := s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumPGs: 10,
+		NumPGsByState: map[string]uint32{
+			"active":   10,
+			"clean":    7,
+			"degraded": 3,
+		},
+	}, nil).Once()
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumPGs: 10,
+		NumPGsByState: map[string]uint32{
+			"active": 10,
+			"clean":  10,
+		},
+	}, nil).NotBefore(call1).Once()
+
+	err := s.svc.WaitForCleanPGs(s.ctx, time.Millisecond)
+	s.Require().NoError(err)
+}
+
+func (s *serviceTestSuite) TestWaitForCleanPGsTimeout() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumPGs: 10,
+		NumPGsByState: map[string]uint32{
+			"active": 10,
+			"clean":  7,
+		},
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err := s.svc.WaitForCleanPGs(ctx, time.Millisecond)
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -19,37 +20,157 @@ func NewMock() *Mock {
 	return &Mock{}
 }
 
+func (m *Mock) ApplyCephAuth(_ context.Context, auth models.CephAuth) error {
+	args := m.Called(auth)
+	return args.Error(0)
+}
+
+func (m *Mock) ApplyCephBalancer(_ context.Context, cfg models.CephBalancer) error {
+	args := m.Called(cfg)
+	return args.Error(0)
+}
+
 func (m *Mock) ApplyCephConfig(_ context.Context, cfg models.CephConfig) error {
 	args := m.Called(cfg)
 	return args.Error(0)
 }
 
+func (m *Mock) ApplyCephFS(_ context.Context, fs models.CephFS) error {
+	args := m.Called(fs)
+	return args.Error(0)
+}
+
+func (m *Mock) ApplyCephHealthMutes(_ context.Context, mutes models.CephHealthMutes) error {
+	args := m.Called(mutes)
+	return args.Error(0)
+}
+
+func (m *Mock) ApplyCephMgrModules(_ context.Context, modules models.CephMgrModules) error {
+	args := m.Called(modules)
+	return args.Error(0)
+}
+
 func (m *Mock) ApplyCephOSDConfig(ctx context.Context, cfg models.CephOSDConfig) error {
 	args := m.Called(cfg)
 	return args.Error(0)
 }
 
+func (m *Mock) ApplyCephOSDFlags(_ context.Context, flags models.CephOSDFlags) error {
+	args := m.Called(flags)
+	return args.Error(0)
+}
+
+func (m *Mock) DiffCephAuth(_ context.Context, auth models.CephAuth) ([]models.CephAuthDifference, error) {
+	args := m.Called(auth)
+	return args.Get(0).([]models.CephAuthDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephBalancer(_ context.Context, cfg models.CephBalancer) ([]models.CephBalancerDifference, error) {
+	args := m.Called(cfg)
+	return args.Get(0).([]models.CephBalancerDifference), args.Error(1)
+}
+
 func (m *Mock) DiffCephConfig(_ context.Context, cfg models.CephConfig) ([]models.CephConfigDifference, error) {
 	args := m.Called(cfg)
 	return args.Get(0).([]models.CephConfigDifference), args.Error(1)
 }
 
+func (m *Mock) DiffCephFS(_ context.Context, fs models.CephFS) ([]models.CephFSDifference, error) {
+	args := m.Called(fs)
+	return args.Get(0).([]models.CephFSDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephHealthMutes(_ context.Context, mutes models.CephHealthMutes) ([]models.CephHealthMutesDifference, error) {
+	args := m.Called(mutes)
+	return args.Get(0).([]models.CephHealthMutesDifference), args.Error(1)
+}
+
+func (m *Mock) DiffCephMgrModules(_ context.Context, modules models.CephMgrModules) ([]models.CephMgrModulesDifference, error) {
+	args := m.Called(modules)
+	return args.Get(0).([]models.CephMgrModulesDifference), args.Error(1)
+}
+
 func (m *Mock) DiffCephOSDConfig(ctx context.Context, cfg models.CephOSDConfig) ([]models.CephOSDConfigDifference, error) {
 	args := m.Called(cfg)
 	return args.Get(0).([]models.CephOSDConfigDifference), args.Error(1)
 }
 
+func (m *Mock) DiffCephOSDFlags(_ context.Context, flags models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error) {
+	args := m.Called(flags)
+	return args.Get(0).([]models.CephOSDFlagsDifference), args.Error(1)
+}
+
 func (m *Mock) CheckClusterHealth(context.Context, []clusterHealth.ClusterHealthCheck) ([]models.ClusterHealthIndicator, error) {
 	args := m.Called()
 	return args.Get(0).([]models.ClusterHealthIndicator), args.Error(1)
 }
 
+func (m *Mock) CheckOkToStop(_ context.Context, who string, destroy bool, _ []clusterHealth.ClusterHealthCheck) (models.OkToStopVerdict, error) {
+	args := m.Called(who, destroy)
+	return args.Get(0).(models.OkToStopVerdict), args.Error(1)
+}
+
+func (m *Mock) ClusterFacts(context.Context) (models.ClusterFacts, error) {
+	args := m.Called()
+	return args.Get(0).(models.ClusterFacts), args.Error(1)
+}
+
+func (m *Mock) DumpAuth(_ context.Context, showSecrets bool) (models.CephAuth, error) {
+	args := m.Called(showSecrets)
+	return args.Get(0).(models.CephAuth), args.Error(1)
+}
+
+func (m *Mock) DumpBalancer(context.Context) (models.CephBalancer, error) {
+	args := m.Called()
+	return args.Get(0).(models.CephBalancer), args.Error(1)
+}
+
 func (m *Mock) DumpConfig(context.Context) (models.CephConfig, error) {
 	args := m.Called()
 	return args.Get(0).(models.CephConfig), args.Error(1)
 }
 
+func (m *Mock) DumpFS(context.Context) (models.CephFS, error) {
+	args := m.Called()
+	return args.Get(0).(models.CephFS), args.Error(1)
+}
+
+func (m *Mock) DumpHealthMutes(context.Context) (models.CephHealthMutes, error) {
+	args := m.Called()
+	return args.Get(0).(models.CephHealthMutes), args.Error(1)
+}
+
+func (m *Mock) DumpMgrModules(context.Context) (models.CephMgrModules, error) {
+	args := m.Called()
+	return args.Get(0).(models.CephMgrModules), args.Error(1)
+}
+
 func (m *Mock) DumpOSDConfig(context.Context) (models.CephOSDConfig, error) {
 	args := m.Called()
 	return args.Get(0).(models.CephOSDConfig), args.Error(1)
 }
+
+func (m *Mock) DumpOSDFlags(context.Context) (models.CephOSDFlags, error) {
+	args := m.Called()
+	return args.Get(0).(models.CephOSDFlags), args.Error(1)
+}
+
+func (m *Mock) EndMaintenance(context.Context) (models.MaintenanceLease, error) {
+	args := m.Called()
+	return args.Get(0).(models.MaintenanceLease), args.Error(1)
+}
+
+func (m *Mock) RecommendOSDMemoryTarget(_ context.Context, reservedBytes uint64, headroom float64) ([]models.OSDMemoryRecommendation, error) {
+	args := m.Called(reservedBytes, headroom)
+	return args.Get(0).([]models.OSDMemoryRecommendation), args.Error(1)
+}
+
+func (m *Mock) StartMaintenance(_ context.Context, who string, ttl time.Duration, checks []clusterHealth.ClusterHealthCheck) (models.MaintenanceLease, error) {
+	args := m.Called(who, ttl)
+	return args.Get(0).(models.MaintenanceLease), args.Error(1)
+}
+
+func (m *Mock) WaitForCleanPGs(_ context.Context, interval time.Duration) error {
+	args := m.Called(interval)
+	return args.Error(0)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/runityru/cephctl/models"
+	clusterHealth "github.com/runityru/cephctl/service/cluster_health"
+)
+
+// CheckOkToStop gives go/no-go answer whether the host or the OSD (i.e.
+// osd.3) could be stopped: Ceph must confirm no PG becomes inactive and
+// each of the checks must be good. If destroy is set OSDs are also required
+// to be safe to destroy.
+func (s *service) CheckOkToStop(ctx context.Context, who string, destroy bool, checks []clusterHealth.ClusterHealthCheck) (models.OkToStopVerdict, error) {
+	cr, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return models.OkToStopVerdict{}, errors.Wrap(err, "error collecting cluster report")
+	}
+
+	ids, err := resolveOSDs(cr, who)
+	if err != nil {
+		return models.OkToStopVerdict{}, err
+	}
+
+	okToStop, err := s.c.OSDOkToStop(ctx, ids)
+	if err != nil {
+		return models.OkToStopVerdict{}, err
+	}
+
+	indicators, err := runChecks(ctx, models.ClusterSnapshot{Report: cr}, checks)
+	if err != nil {
+		return models.OkToStopVerdict{}, err
+	}
+
+	reasons := []string{}
+	if !okToStop.OkToStop {
+		reasons = append(reasons, fmt.Sprintf("%d PG(s) are or would become inactive", okToStop.NumNotOkPGs))
+	}
+
+	safeToDestroy := false
+	if destroy {
+		std, err := s.c.OSDSafeToDestroy(ctx, ids)
+		if err != nil {
+			return models.OkToStopVerdict{}, err
+		}
+		safeToDestroy = len(std.SafeToDestroy) == len(ids)
+
+		for _, r := range []struct {
+			ids    []uint16
+			format string
+		}{
+			{std.Active, "still up: %s"},
+			{std.StoredPGs, "PGs are still stored on: %s"},
+			{std.MissingStats, "no PG stats reported for: %s"},
+		} {
+			if len(r.ids) > 0 {
+				reasons = append(reasons, fmt.Sprintf(r.format, osdNames(r.ids)))
+			}
+		}
+	}
+
+	for _, indicator := range indicators {
+		if indicator.CurrentValueStatus != models.ClusterHealthIndicatorStatusGood {
+			reasons = append(reasons, fmt.Sprintf("%s = %s", indicator.Indicator, indicator.CurrentValue))
+		}
+	}
+
+	return models.OkToStopVerdict{
+		Who:           who,
+		OSDs:          ids,
+		Go:            len(reasons) == 0,
+		SafeToDestroy: safeToDestroy,
+		Reasons:       reasons,
+	}, nil
+}
+
+func osdNames(ids []uint16) string {
+	names := []string{}
+	for _, id := range ids {
+		names = append(names, fmt.Sprintf("osd.%d", id))
+	}
+	return strings.Join(names, ", ")
+}
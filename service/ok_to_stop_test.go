@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/runityru/cephctl/models"
+	clusterHeath "github.com/runityru/cephctl/service/cluster_health"
+)
+
+func (s *serviceTestSuite) TestCheckOkToStopGo() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumMons:         3,
+		NumMonsInQuorum: 3,
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 1, Hostname: "nuc01"},
+			{ID: 0, Hostname: "nuc01"},
+			{ID: 2, Hostname: "nuc02"},
+		},
+	}, nil).Once()
+	s.cephMock.On("OSDOkToStop", []uint16{0, 1}).Return(models.OSDOkToStop{
+		OkToStop: true,
+		OSDs:     []uint16{0, 1},
+		NumOkPGs: 42,
+	}, nil).Once()
+
+	verdict, err := s.svc.CheckOkToStop(s.ctx, "nuc01", false, []clusterHeath.ClusterHealthCheck{
+		clusterHeath.Quorum,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(models.OkToStopVerdict{
+		Who:           "nuc01",
+		OSDs:          []uint16{0, 1},
+		Go:            true,
+		SafeToDestroy: false,
+		Reasons:       []string{},
+	}, verdict)
+}
+
+func (s *serviceTestSuite) TestCheckOkToStopNoGo() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumMons:         3,
+		NumMonsInQuorum: 2,
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 3, Hostname: "nuc02"},
+		},
+	}, nil).Once()
+	s.cephMock.On("OSDOkToStop", []uint16{3}).Return(models.OSDOkToStop{
+		OkToStop:    false,
+		OSDs:        []uint16{3},
+		NumNotOkPGs: 5,
+	}, nil).Once()
+	s.cephMock.On("OSDSafeToDestroy", []uint16{3}).Return(models.OSDSafeToDestroy{
+		Active:    []uint16{3},
+		StoredPGs: []uint16{3},
+	}, nil).Once()
+
+	verdict, err := s.svc.CheckOkToStop(s.ctx, "osd.3", true, []clusterHeath.ClusterHealthCheck{
+		clusterHeath.Quorum,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(models.OkToStopVerdict{
+		Who:  "osd.3",
+		OSDs: []uint16{3},
+		Go:   false,
+		Reasons: []string{
+			"5 PG(s) are or would become inactive",
+			"still up: osd.3",
+			"PGs are still stored on: osd.3",
+			"QUORUM = 2 of 3",
+		},
+	}, verdict)
+}
+
+func (s *serviceTestSuite) TestCheckOkToStopUnknownTarget() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 3, Hostname: "nuc02"},
+		},
+	}, nil).Once()
+
+	_, err := s.svc.CheckOkToStop(s.ctx, "nuc05", false, nil)
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrUnknownTarget)
+}
@@ -2,9 +2,15 @@ package service
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"github.com/teran/go-ptr"
 
 	"github.com/runityru/cephctl/ceph"
 	"github.com/runityru/cephctl/differ"
@@ -13,15 +19,51 @@ import (
 )
 
 type Service interface {
+	ApplyCephAuth(ctx context.Context, auth models.CephAuth) error
+	ApplyCephBalancer(ctx context.Context, cfg models.CephBalancer) error
 	ApplyCephConfig(ctx context.Context, cfg models.CephConfig) error
+	ApplyCephFS(ctx context.Context, fs models.CephFS) error
+	ApplyCephHealthMutes(ctx context.Context, mutes models.CephHealthMutes) error
+	ApplyCephMgrModules(ctx context.Context, modules models.CephMgrModules) error
 	ApplyCephOSDConfig(ctx context.Context, cfg models.CephOSDConfig) error
+	ApplyCephOSDFlags(ctx context.Context, flags models.CephOSDFlags) error
+	DiffCephAuth(ctx context.Context, auth models.CephAuth) ([]models.CephAuthDifference, error)
+	DiffCephBalancer(ctx context.Context, cfg models.CephBalancer) ([]models.CephBalancerDifference, error)
 	DiffCephConfig(ctx context.Context, cfg models.CephConfig) ([]models.CephConfigDifference, error)
+	DiffCephFS(ctx context.Context, fs models.CephFS) ([]models.CephFSDifference, error)
+	DiffCephHealthMutes(ctx context.Context, mutes models.CephHealthMutes) ([]models.CephHealthMutesDifference, error)
+	DiffCephMgrModules(ctx context.Context, modules models.CephMgrModules) ([]models.CephMgrModulesDifference, error)
 	DiffCephOSDConfig(ctx context.Context, cfg models.CephOSDConfig) ([]models.CephOSDConfigDifference, error)
+	DiffCephOSDFlags(ctx context.Context, flags models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error)
 	CheckClusterHealth(ctx context.Context, checks []clusterHealth.ClusterHealthCheck) ([]models.ClusterHealthIndicator, error)
+	CheckOkToStop(ctx context.Context, who string, destroy bool, checks []clusterHealth.ClusterHealthCheck) (models.OkToStopVerdict, error)
+	ClusterFacts(ctx context.Context) (models.ClusterFacts, error)
+	DumpAuth(ctx context.Context, showSecrets bool) (models.CephAuth, error)
+	DumpBalancer(ctx context.Context) (models.CephBalancer, error)
 	DumpConfig(ctx context.Context) (models.CephConfig, error)
+	DumpFS(ctx context.Context) (models.CephFS, error)
+	DumpHealthMutes(ctx context.Context) (models.CephHealthMutes, error)
+	DumpMgrModules(ctx context.Context) (models.CephMgrModules, error)
 	DumpOSDConfig(ctx context.Context) (models.CephOSDConfig, error)
+	DumpOSDFlags(ctx context.Context) (models.CephOSDFlags, error)
+	EndMaintenance(ctx context.Context) (models.MaintenanceLease, error)
+	RecommendOSDMemoryTarget(ctx context.Context, reservedBytes uint64, headroom float64) ([]models.OSDMemoryRecommendation, error)
+	StartMaintenance(ctx context.Context, who string, ttl time.Duration, checks []clusterHealth.ClusterHealthCheck) (models.MaintenanceLease, error)
+	WaitForCleanPGs(ctx context.Context, interval time.Duration) error
 }
 
+const (
+	// upmapMaxDeviationOption is the balancer module option upmap_max_deviation
+	// is stored in
+	upmapMaxDeviationOption = "mgr/balancer/upmap_max_deviation"
+	// upmapMaxDeviationDefault is the default value of upmap_max_deviation
+	upmapMaxDeviationDefault = 5
+)
+
+// ErrBalancerOption is returned when CephConfig specification declares the
+// option managed by CephBalancer
+var ErrBalancerOption = errors.New("option is managed by CephBalancer")
+
 type service struct {
 	c ceph.Ceph
 	d differ.Differ
@@ -34,6 +76,87 @@ func New(c ceph.Ceph, d differ.Differ) Service {
 	}
 }
 
+// ApplyCephAuth updates caps of the existing entities only, all the caps
+// of the entity are set at once since `ceph auth caps` replaces them
+func (s *service) ApplyCephAuth(ctx context.Context, auth models.CephAuth) error {
+	entities, err := s.c.ListAuthEntities(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving auth entities")
+	}
+
+	changes, err := s.d.DiffCephAuth(ctx, entities, auth)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired auth entities")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	caps := map[string]map[string]string{}
+	for _, e := range entities {
+		caps[e.Name] = maps.Clone(e.Caps)
+	}
+
+	names := []string{}
+	for _, change := range changes {
+		if caps[change.Entity] == nil {
+			caps[change.Entity] = map[string]string{}
+		}
+
+		switch change.Kind {
+		case models.CephAuthDifferenceKindAdd, models.CephAuthDifferenceKindChange:
+			caps[change.Entity][change.Daemon] = change.Caps
+		case models.CephAuthDifferenceKindRemove:
+			delete(caps[change.Entity], change.Daemon)
+		default:
+			log.Warnf("unexpected change kind: %s", change.Kind)
+			continue
+		}
+
+		if !slices.Contains(names, change.Entity) {
+			names = append(names, change.Entity)
+		}
+	}
+
+	for _, name := range names {
+		if err := s.c.SetAuthCaps(ctx, name, caps[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) ApplyCephBalancer(ctx context.Context, cfg models.CephBalancer) error {
+	changes, err := s.DiffCephBalancer(ctx, cfg)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired configuration")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	for _, change := range changes {
+		var err error
+		switch change.Key {
+		case "mode":
+			err = s.c.SetBalancerMode(ctx, change.Value)
+		case "active":
+			err = s.c.SetBalancerActive(ctx, change.Value == "true")
+		case "upmap_max_deviation":
+			err = s.c.ApplyCephConfigOption(ctx, "mgr", upmapMaxDeviationOption, change.Value)
+		default:
+			log.Warnf("unexpected key: %s", change.Key)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *service) ApplyCephConfig(ctx context.Context, cfg models.CephConfig) error {
 	changes, err := s.DiffCephConfig(ctx, cfg)
 	if err != nil {
@@ -47,11 +170,83 @@ func (s *service) ApplyCephConfig(ctx context.Context, cfg models.CephConfig) er
 	for _, change := range changes {
 		switch change.Kind {
 		case models.CephConfigDifferenceKindRemove:
-			if err := s.c.RemoveCephConfigOption(ctx, change.Section, change.Key); err != nil {
+			if err := s.c.RemoveCephConfigOption(ctx, change.Target(), change.Key); err != nil {
 				return err
 			}
 		case models.CephConfigDifferenceKindAdd, models.CephConfigDifferenceKindChange:
-			if err := s.c.ApplyCephConfigOption(ctx, change.Section, change.Key, *change.Value); err != nil {
+			if err := s.c.ApplyCephConfigOption(ctx, change.Target(), change.Key, *change.Value); err != nil {
+				return err
+			}
+		default:
+			log.Warnf("unexpected change kind: %s", change.Kind)
+		}
+	}
+	return nil
+}
+
+func (s *service) ApplyCephFS(ctx context.Context, fs models.CephFS) error {
+	changes, err := s.DiffCephFS(ctx, fs)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired filesystems settings")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	for _, change := range changes {
+		if err := s.c.SetFileSystemOption(ctx, change.FileSystem, change.Key, change.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) ApplyCephHealthMutes(ctx context.Context, mutes models.CephHealthMutes) error {
+	changes, err := s.DiffCephHealthMutes(ctx, mutes)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired health mutes")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	for _, change := range changes {
+		switch change.Kind {
+		case models.CephHealthMutesDifferenceKindMute, models.CephHealthMutesDifferenceKindChange:
+			if err := s.c.MuteHealthCheck(ctx, change.Code, change.Mute.TTL, change.Mute.Sticky); err != nil {
+				return err
+			}
+		case models.CephHealthMutesDifferenceKindUnmute:
+			if err := s.c.UnmuteHealthCheck(ctx, change.Code); err != nil {
+				return err
+			}
+		default:
+			log.Warnf("unexpected change kind: %s", change.Kind)
+		}
+	}
+	return nil
+}
+
+func (s *service) ApplyCephMgrModules(ctx context.Context, modules models.CephMgrModules) error {
+	changes, err := s.DiffCephMgrModules(ctx, modules)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired manager modules")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	for _, change := range changes {
+		switch change.Kind {
+		case models.CephMgrModulesDifferenceKindEnable:
+			if err := s.c.EnableMgrModule(ctx, change.Module); err != nil {
+				return err
+			}
+		case models.CephMgrModulesDifferenceKindDisable:
+			if err := s.c.DisableMgrModule(ctx, change.Module); err != nil {
 				return err
 			}
 		default:
@@ -79,8 +274,40 @@ func (s *service) ApplyCephOSDConfig(ctx context.Context, cfg models.CephOSDConf
 	return nil
 }
 
+func (s *service) ApplyCephOSDFlags(ctx context.Context, flags models.CephOSDFlags) error {
+	changes, err := s.DiffCephOSDFlags(ctx, flags)
+	if err != nil {
+		return errors.Wrap(err, "error comparing current and desired flags")
+	}
+
+	log.WithFields(log.Fields{
+		"component": "service",
+	}).Tracef("changelog: %#v", changes)
+
+	for _, change := range changes {
+		switch change.Kind {
+		case models.CephOSDFlagsDifferenceKindSet:
+			if err := s.c.SetOSDFlag(ctx, change.Flag, change.Who); err != nil {
+				return err
+			}
+		case models.CephOSDFlagsDifferenceKindUnset:
+			if err := s.c.UnsetOSDFlag(ctx, change.Flag, change.Who); err != nil {
+				return err
+			}
+		default:
+			log.Warnf("unexpected change kind: %s", change.Kind)
+		}
+	}
+	return nil
+}
+
 func (s *service) CheckClusterHealth(ctx context.Context, checks []clusterHealth.ClusterHealthCheck) ([]models.ClusterHealthIndicator, error) {
 	cr, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving cluster report")
+	}
+
+	status, err := s.c.ClusterStatus(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving cluster status")
 	}
@@ -90,11 +317,65 @@ func (s *service) CheckClusterHealth(ctx context.Context, checks []clusterHealth
 		return nil, errors.Wrap(err, "error retrieving device list")
 	}
 
-	cr.Devices = devices
+	lease, err := s.c.GetMaintenanceLease(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving maintenance lease")
+	}
+
+	// Balancer status is reported by manager so it's not available while
+	// managers are down, the indicators relying on it are reported as unknown
+	var balancer *models.BalancerStatus
+	if bs, err := s.c.BalancerStatus(ctx); err != nil {
+		log.Warnf("error retrieving balancer status: %s", err)
+	} else {
+		balancer = &bs
+	}
+
+	// OSD usage is reported by manager as well
+	osdUsage, err := s.c.ListOSDUsage(ctx)
+	if err != nil {
+		log.Warnf("error retrieving OSD usage: %s", err)
+		osdUsage = nil
+	}
+
+	cfg, err := s.c.DumpConfig(ctx)
+	if err != nil {
+		log.Warnf("error retrieving configuration: %s", err)
+		cfg = nil
+	}
+
+	// Report has no versions of mon, mgr and mds daemons and could miss
+	// some of the RGWs so `ceph versions` is preferred for each of the
+	// daemon types it reports. Versions from report only are not enough
+	// to tell the skew so they're dropped when `ceph versions` fails
+	versions, err := s.c.DaemonVersions(ctx)
+	if err != nil {
+		log.Warnf("error retrieving daemon versions: %s", err)
+		cr.NumDaemonsByVersion = nil
+	} else {
+		if cr.NumDaemonsByVersion == nil {
+			cr.NumDaemonsByVersion = map[string]map[string]uint16{}
+		}
+		for daemonType, v := range versions {
+			cr.NumDaemonsByVersion[daemonType] = v
+		}
+	}
+
+	return runChecks(ctx, models.ClusterSnapshot{
+		Report:      cr,
+		Status:      status,
+		Devices:     devices,
+		Maintenance: lease,
+		Balancer:    balancer,
+		OSDUsage:    osdUsage,
+		Config:      cfg,
+	}, checks)
+}
 
+func runChecks(ctx context.Context, cs models.ClusterSnapshot, checks []clusterHealth.ClusterHealthCheck) ([]models.ClusterHealthIndicator, error) {
 	indicators := []models.ClusterHealthIndicator{}
 	for _, checkFunc := range checks {
-		indicator, err := checkFunc(ctx, cr)
+		indicator, err := checkFunc(ctx, cs)
 		if err != nil {
 			return nil, err
 		}
@@ -105,13 +386,97 @@ func (s *service) CheckClusterHealth(ctx context.Context, checks []clusterHealth
 	return indicators, nil
 }
 
+func (s *service) ClusterFacts(ctx context.Context) (models.ClusterFacts, error) {
+	cr, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return models.ClusterFacts{}, errors.Wrap(err, "error collecting cluster report")
+	}
+
+	hosts := map[string]models.HostFacts{}
+	for _, osd := range cr.OSDDaemons {
+		h := hosts[osd.Hostname]
+		h.NumOSDs++
+		// All of the OSDs on the host report the same amount of memory
+		h.MemoryTotalBytes = osd.MemoryTotalBytes
+		hosts[osd.Hostname] = h
+	}
+
+	var minMemoryPerOSD uint64
+	for hostname, h := range hosts {
+		h.MemoryPerOSDBytes = h.MemoryTotalBytes / uint64(h.NumOSDs)
+		hosts[hostname] = h
+
+		if minMemoryPerOSD == 0 || h.MemoryPerOSDBytes < minMemoryPerOSD {
+			minMemoryPerOSD = h.MemoryPerOSDBytes
+		}
+	}
+
+	return models.ClusterFacts{
+		NumOSDs:              cr.NumOSDs,
+		NumHosts:             uint16(len(hosts)),
+		MinMemoryPerOSDBytes: minMemoryPerOSD,
+		Hosts:                hosts,
+	}, nil
+}
+
+func (s *service) DiffCephAuth(ctx context.Context, auth models.CephAuth) ([]models.CephAuthDifference, error) {
+	entities, err := s.c.ListAuthEntities(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving auth entities")
+	}
+
+	return s.d.DiffCephAuth(ctx, entities, auth)
+}
+
+func (s *service) DiffCephBalancer(ctx context.Context, cfg models.CephBalancer) ([]models.CephBalancerDifference, error) {
+	src, err := s.DumpBalancer(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving current configuration")
+	}
+
+	return s.d.DiffCephBalancer(ctx, src, cfg)
+}
+
+// DiffCephConfig compares the configuration without the options managed by
+// CephBalancer so they're not removed as undeclared ones
 func (s *service) DiffCephConfig(ctx context.Context, cfg models.CephConfig) ([]models.CephConfigDifference, error) {
+	if _, ok := cfg["mgr"][upmapMaxDeviationOption]; ok {
+		return nil, errors.Wrap(ErrBalancerOption, upmapMaxDeviationOption)
+	}
+
 	src, err := s.c.DumpConfig(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving current configuration")
 	}
 
-	return s.d.DiffCephConfig(ctx, src, cfg)
+	return s.d.DiffCephConfig(ctx, withoutBalancerOptions(src), cfg)
+}
+
+func (s *service) DiffCephFS(ctx context.Context, fs models.CephFS) ([]models.CephFSDifference, error) {
+	filesystems, err := s.c.ListFileSystems(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving filesystems")
+	}
+
+	return s.d.DiffCephFS(ctx, filesystems, fs)
+}
+
+func (s *service) DiffCephHealthMutes(ctx context.Context, mutes models.CephHealthMutes) ([]models.CephHealthMutesDifference, error) {
+	st, err := s.c.ClusterStatus(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving cluster status")
+	}
+
+	return s.d.DiffCephHealthMutes(ctx, st.MutedChecks, mutes)
+}
+
+func (s *service) DiffCephMgrModules(ctx context.Context, modules models.CephMgrModules) ([]models.CephMgrModulesDifference, error) {
+	src, err := s.c.ListMgrModules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving manager modules")
+	}
+
+	return s.d.DiffCephMgrModules(ctx, src, modules)
 }
 
 func (s *service) DiffCephOSDConfig(ctx context.Context, cfg models.CephOSDConfig) ([]models.CephOSDConfigDifference, error) {
@@ -123,8 +488,137 @@ func (s *service) DiffCephOSDConfig(ctx context.Context, cfg models.CephOSDConfi
 	return s.d.DiffCephOSDConfig(ctx, src, cfg)
 }
 
+func (s *service) DiffCephOSDFlags(ctx context.Context, flags models.CephOSDFlags) ([]models.CephOSDFlagsDifference, error) {
+	src, err := s.DumpOSDFlags(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving current flags")
+	}
+
+	return s.d.DiffCephOSDFlags(ctx, src, flags)
+}
+
+// DumpAuth returns caps of all the entities, keys are returned only
+// if showSecrets is set
+func (s *service) DumpAuth(ctx context.Context, showSecrets bool) (models.CephAuth, error) {
+	entities, err := s.c.ListAuthEntities(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving auth entities")
+	}
+
+	// Entities without caps are skipped since empty caps could not be
+	// applied
+	auth := models.CephAuth{}
+	for _, e := range entities {
+		if len(e.Caps) == 0 {
+			continue
+		}
+
+		entity := models.CephAuthEntity{
+			Caps: e.Caps,
+		}
+		if showSecrets {
+			entity.Key = e.Key
+		}
+		auth[e.Name] = entity
+	}
+
+	return auth, nil
+}
+
+func (s *service) DumpBalancer(ctx context.Context) (models.CephBalancer, error) {
+	st, err := s.c.BalancerStatus(ctx)
+	if err != nil {
+		return models.CephBalancer{}, errors.Wrap(err, "error retrieving balancer status")
+	}
+
+	cfg, err := s.c.DumpConfig(ctx)
+	if err != nil {
+		return models.CephBalancer{}, errors.Wrap(err, "error retrieving current configuration")
+	}
+
+	deviation := uint64(upmapMaxDeviationDefault)
+	if v, ok := cfg["mgr"][upmapMaxDeviationOption]; ok {
+		deviation, err = strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return models.CephBalancer{}, errors.Wrapf(err, "error parsing %s value", upmapMaxDeviationOption)
+		}
+	}
+
+	return models.CephBalancer{
+		Mode:              st.Mode,
+		Active:            st.Active,
+		UpmapMaxDeviation: uint16(deviation),
+	}, nil
+}
+
 func (s *service) DumpConfig(ctx context.Context) (models.CephConfig, error) {
-	return s.c.DumpConfig(ctx)
+	cfg, err := s.c.DumpConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return withoutBalancerOptions(cfg), nil
+}
+
+func (s *service) DumpFS(ctx context.Context) (models.CephFS, error) {
+	filesystems, err := s.c.ListFileSystems(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving filesystems")
+	}
+
+	cfg := models.CephFS{}
+	for _, fs := range filesystems {
+		cfg[fs.Name] = models.CephFSSettings{
+			MaxMDS:             ptr.Uint16(fs.MaxMDS),
+			AllowStandbyReplay: ptr.Bool(fs.AllowStandbyReplay),
+			SessionTimeout:     ptr.Uint32(fs.SessionTimeout),
+			MaxFileSize:        ptr.Uint64(fs.MaxFileSize),
+			Joinable:           ptr.Bool(fs.Joinable),
+		}
+	}
+
+	return cfg, nil
+}
+
+// DumpHealthMutes returns mutes set on the cluster with TTL remaining,
+// expired mutes are skipped
+func (s *service) DumpHealthMutes(ctx context.Context) (models.CephHealthMutes, error) {
+	st, err := s.c.ClusterStatus(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving cluster status")
+	}
+
+	mutes := models.CephHealthMutes{}
+	for _, m := range st.MutedChecks {
+		mute := models.CephHealthMute{
+			Sticky: m.Sticky,
+		}
+
+		if m.ExpiresAt != nil {
+			ttl := time.Until(*m.ExpiresAt).Truncate(time.Second)
+			if ttl <= 0 {
+				continue
+			}
+			mute.TTL = ttl
+		}
+
+		mutes[m.Code] = mute
+	}
+
+	return mutes, nil
+}
+
+// DumpMgrModules returns manager modules state as specification, always-on
+// modules are not the part of it since they couldn't be managed
+func (s *service) DumpMgrModules(ctx context.Context) (models.CephMgrModules, error) {
+	modules, err := s.c.ListMgrModules(ctx)
+	if err != nil {
+		return models.CephMgrModules{}, errors.Wrap(err, "error retrieving manager modules")
+	}
+
+	return models.CephMgrModules{
+		Enabled:  modules.Enabled,
+		Disabled: modules.Disabled,
+	}, nil
 }
 
 func (s *service) DumpOSDConfig(ctx context.Context) (models.CephOSDConfig, error) {
@@ -141,3 +635,90 @@ func (s *service) DumpOSDConfig(ctx context.Context) (models.CephOSDConfig, erro
 		RequireMinCompatClient: rep.RequireMinCompatClient,
 	}, nil
 }
+
+func (s *service) DumpOSDFlags(ctx context.Context) (models.CephOSDFlags, error) {
+	rep, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return models.CephOSDFlags{}, errors.Wrap(err, "error collecting cluster report")
+	}
+
+	return rep.OSDFlags, nil
+}
+
+// osdMemoryTargetMinBytes is the minimal value of osd_memory_target
+// allowed by Ceph
+const osdMemoryTargetMinBytes = 896 << 20
+
+// RecommendOSDMemoryTarget computes osd_memory_target for each host running
+// OSDs: reserved amount of memory is left for OS and other daemons, the
+// rest of memory except headroom ratio (OSDs could exceed the target for a
+// while) is shared equally between OSDs.
+func (s *service) RecommendOSDMemoryTarget(ctx context.Context, reservedBytes uint64, headroom float64) ([]models.OSDMemoryRecommendation, error) {
+	if headroom < 0 || headroom >= 1 {
+		return nil, errors.Errorf("headroom must be in [0, 1) range but %v is given", headroom)
+	}
+
+	cr, err := s.c.ClusterReport(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error collecting cluster report")
+	}
+
+	hosts := map[string]*models.OSDMemoryRecommendation{}
+	for _, osd := range cr.OSDDaemons {
+		h, ok := hosts[osd.Hostname]
+		if !ok {
+			h = &models.OSDMemoryRecommendation{
+				Hostname:         osd.Hostname,
+				MemoryTotalBytes: osd.MemoryTotalBytes,
+			}
+			hosts[osd.Hostname] = h
+		}
+
+		h.NumOSDs++
+		if osd.IsRotational {
+			h.NumRotationalOSDs++
+		}
+	}
+
+	recommendations := []models.OSDMemoryRecommendation{}
+	for _, h := range hosts {
+		var available uint64
+		if h.MemoryTotalBytes > reservedBytes {
+			available = uint64(float64(h.MemoryTotalBytes-reservedBytes) * (1 - headroom))
+		}
+
+		// Round down to MiB to keep values readable
+		h.OSDMemoryTargetBytes = ((available / uint64(h.NumOSDs)) >> 20) << 20
+		if h.OSDMemoryTargetBytes < osdMemoryTargetMinBytes {
+			h.OSDMemoryTargetBytes = osdMemoryTargetMinBytes
+			h.Insufficient = true
+		}
+
+		recommendations = append(recommendations, *h)
+	}
+
+	slices.SortFunc(recommendations, func(a, b models.OSDMemoryRecommendation) int {
+		return strings.Compare(a.Hostname, b.Hostname)
+	})
+
+	return recommendations, nil
+}
+
+// withoutBalancerOptions returns copy of the configuration without options
+// managed by CephBalancer
+func withoutBalancerOptions(cfg models.CephConfig) models.CephConfig {
+	out := models.CephConfig{}
+	for target, options := range cfg {
+		for k, v := range options {
+			if target == "mgr" && k == upmapMaxDeviationOption {
+				continue
+			}
+
+			if _, ok := out[target]; !ok {
+				out[target] = map[string]string{}
+			}
+			out[target][k] = v
+		}
+	}
+	return out
+}
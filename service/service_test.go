@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
 	ptr "github.com/teran/go-ptr"
@@ -20,6 +21,69 @@ func init() {
 	log.SetLevel(log.TraceLevel)
 }
 
+func (s *serviceTestSuite) TestApplyCephAuth() {
+	auth := models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mgr": "profile rbd pool=rbd",
+				"mon": "profile rbd",
+			},
+		},
+	}
+	entities := []models.AuthEntity{
+		{
+			Name: "client.rbd-prod",
+			Key:  "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+			Caps: map[string]string{
+				"mds": "allow",
+				"mon": "profile rbd",
+			},
+		},
+	}
+
+	call1 := s.cephMock.On("ListAuthEntities").Return(entities, nil).Once()
+	call2 := s.differMock.On("DiffCephAuth", entities, auth).Return([]models.CephAuthDifference{
+		{Kind: models.CephAuthDifferenceKindRemove, Entity: "client.rbd-prod", Daemon: "mds", OldCaps: "allow"},
+		{Kind: models.CephAuthDifferenceKindAdd, Entity: "client.rbd-prod", Daemon: "mgr", Caps: "profile rbd pool=rbd"},
+	}, nil).NotBefore(call1).Once()
+	s.cephMock.On("SetAuthCaps", "client.rbd-prod", map[string]string{
+		"mgr": "profile rbd pool=rbd",
+		"mon": "profile rbd",
+	}).Return(nil).NotBefore(call2).Once()
+
+	err := s.svc.ApplyCephAuth(s.ctx, auth)
+	s.Require().NoError(err)
+}
+
+func (s *serviceTestSuite) TestApplyCephBalancer() {
+	desired := models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 1,
+	}
+
+	call1 := s.cephMock.On("BalancerStatus").Return(models.BalancerStatus{
+		Mode:   "crush-compat",
+		Active: false,
+	}, nil).Once()
+	call2 := s.cephMock.On("DumpConfig").Return(models.CephConfig{}, nil).NotBefore(call1).Once()
+	call3 := s.differMock.On("DiffCephBalancer", models.CephBalancer{
+		Mode:              "crush-compat",
+		Active:            false,
+		UpmapMaxDeviation: 5,
+	}, desired).Return([]models.CephBalancerDifference{
+		{Key: "mode", OldValue: "crush-compat", Value: "upmap"},
+		{Key: "upmap_max_deviation", OldValue: "5", Value: "1"},
+		{Key: "active", OldValue: "false", Value: "true"},
+	}, nil).NotBefore(call2).Once()
+	call4 := s.cephMock.On("SetBalancerMode", "upmap").Return(nil).NotBefore(call3).Once()
+	call5 := s.cephMock.On("ApplyCephConfigOption", "mgr", "mgr/balancer/upmap_max_deviation", "1").Return(nil).NotBefore(call4).Once()
+	s.cephMock.On("SetBalancerActive", true).Return(nil).NotBefore(call5).Once()
+
+	err := s.svc.ApplyCephBalancer(s.ctx, desired)
+	s.Require().NoError(err)
+}
+
 func (s *serviceTestSuite) TestApplyCephConfig() {
 	currentConfig := models.CephConfig{
 		"osd": {
@@ -70,6 +134,128 @@ func (s *serviceTestSuite) TestApplyCephConfig() {
 	s.Require().NoError(err)
 }
 
+func (s *serviceTestSuite) TestApplyCephConfigMasked() {
+	currentConfig := models.CephConfig{
+		"osd/class:hdd": {
+			"osd_recovery_sleep": "0.1",
+		},
+	}
+	newConfig := models.CephConfig{
+		"osd/host:nuc01": {
+			"osd_memory_target": "8589934592",
+		},
+	}
+	result := []models.CephConfigDifference{
+		{
+			Kind:    models.CephConfigDifferenceKindAdd,
+			Section: "osd",
+			Mask:    "host:nuc01",
+			Key:     "osd_memory_target",
+			Value:   ptr.String("8589934592"),
+		},
+		{
+			Kind:    models.CephConfigDifferenceKindRemove,
+			Section: "osd",
+			Mask:    "class:hdd",
+			Key:     "osd_recovery_sleep",
+		},
+	}
+
+	cephDumpConfig := s.cephMock.On("DumpConfig").Return(currentConfig, nil).Once()
+
+	s.differMock.On("DiffCephConfig", currentConfig, newConfig).Return(result, nil).Once()
+
+	s.cephMock.On("ApplyCephConfigOption", "osd/host:nuc01", "osd_memory_target", "8589934592").Return(nil).NotBefore(cephDumpConfig).Once()
+	s.cephMock.On("RemoveCephConfigOption", "osd/class:hdd", "osd_recovery_sleep").Return(nil).NotBefore(cephDumpConfig).Once()
+
+	err := s.svc.ApplyCephConfig(s.ctx, newConfig)
+	s.Require().NoError(err)
+}
+
+func (s *serviceTestSuite) TestApplyCephFS() {
+	fs := models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(2),
+			AllowStandbyReplay: ptr.Bool(true),
+		},
+	}
+	filesystems := []models.FileSystem{
+		{
+			Name:               "shared-data",
+			MaxMDS:             1,
+			AllowStandbyReplay: false,
+			SessionTimeout:     60,
+			MaxFileSize:        1099511627776,
+			Joinable:           true,
+		},
+	}
+
+	call1 := s.cephMock.On("ListFileSystems").Return(filesystems, nil).Once()
+	call2 := s.differMock.On("DiffCephFS", filesystems, fs).Return([]models.CephFSDifference{
+		{FileSystem: "shared-data", Key: "max_mds", OldValue: "1", Value: "2"},
+		{FileSystem: "shared-data", Key: "allow_standby_replay", OldValue: "false", Value: "true"},
+	}, nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("SetFileSystemOption", "shared-data", "max_mds", "2").Return(nil).NotBefore(call2).Once()
+	s.cephMock.On("SetFileSystemOption", "shared-data", "allow_standby_replay", "true").Return(nil).NotBefore(call3).Once()
+
+	err := s.svc.ApplyCephFS(s.ctx, fs)
+	s.Require().NoError(err)
+}
+
+func (s *serviceTestSuite) TestApplyCephHealthMutes() {
+	mutes := models.CephHealthMutes{
+		"OSDMAP_FLAGS": {
+			TTL:           4 * time.Hour,
+			Sticky:        true,
+			Justification: "maintenance of nuc01",
+		},
+		"OSD_NEARFULL": {
+			TTL: time.Hour,
+		},
+	}
+	current := []models.ClusterStatusMutedCheck{
+		{Code: "POOL_NO_REDUNDANCY"},
+	}
+
+	call1 := s.cephMock.On("ClusterStatus").Return(models.ClusterStatus{
+		MutedChecks: current,
+	}, nil).Once()
+	call2 := s.differMock.On("DiffCephHealthMutes", current, mutes).Return([]models.CephHealthMutesDifference{
+		{Kind: models.CephHealthMutesDifferenceKindMute, Code: "OSDMAP_FLAGS", Mute: mutes["OSDMAP_FLAGS"]},
+		{Kind: models.CephHealthMutesDifferenceKindChange, Code: "OSD_NEARFULL", Mute: mutes["OSD_NEARFULL"]},
+		{Kind: models.CephHealthMutesDifferenceKindUnmute, Code: "POOL_NO_REDUNDANCY"},
+	}, nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("MuteHealthCheck", "OSDMAP_FLAGS", 4*time.Hour, true).Return(nil).NotBefore(call2).Once()
+	call4 := s.cephMock.On("MuteHealthCheck", "OSD_NEARFULL", time.Hour, false).Return(nil).NotBefore(call3).Once()
+	s.cephMock.On("UnmuteHealthCheck", "POOL_NO_REDUNDANCY").Return(nil).NotBefore(call4).Once()
+
+	err := s.svc.ApplyCephHealthMutes(s.ctx, mutes)
+	s.Require().NoError(err)
+}
+
+func (s *serviceTestSuite) TestApplyCephMgrModules() {
+	current := models.MgrModules{
+		AlwaysOn: []string{"balancer"},
+		Enabled:  []string{"restful"},
+		Disabled: []string{"prometheus"},
+	}
+	desired := models.CephMgrModules{
+		Enabled:  []string{"prometheus"},
+		Disabled: []string{"restful"},
+	}
+
+	call1 := s.cephMock.On("ListMgrModules").Return(current, nil).Once()
+	call2 := s.differMock.On("DiffCephMgrModules", current, desired).Return([]models.CephMgrModulesDifference{
+		{Kind: models.CephMgrModulesDifferenceKindEnable, Module: "prometheus"},
+		{Kind: models.CephMgrModulesDifferenceKindDisable, Module: "restful"},
+	}, nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("EnableMgrModule", "prometheus").Return(nil).NotBefore(call2).Once()
+	s.cephMock.On("DisableMgrModule", "restful").Return(nil).NotBefore(call3).Once()
+
+	err := s.svc.ApplyCephMgrModules(s.ctx, desired)
+	s.Require().NoError(err)
+}
+
 func (s *serviceTestSuite) TestApplyCephOSDConfig() {
 	newCfg := models.CephOSDConfig{
 		AllowCrimson:           true,
@@ -99,6 +285,33 @@ func (s *serviceTestSuite) TestApplyCephOSDConfig() {
 	s.Require().NoError(err)
 }
 
+func (s *serviceTestSuite) TestApplyCephOSDFlags() {
+	current := models.CephOSDFlags{
+		Cluster: []string{"noout"},
+		CRUSHNodes: map[string][]string{
+			"nuc01": {"noout"},
+		},
+	}
+	desired := models.CephOSDFlags{
+		Cluster: []string{"noscrub"},
+	}
+
+	call1 := s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDFlags: current,
+	}, nil).Once()
+	call2 := s.differMock.On("DiffCephOSDFlags", current, desired).Return([]models.CephOSDFlagsDifference{
+		{Kind: models.CephOSDFlagsDifferenceKindSet, Flag: "noscrub"},
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "noout"},
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Who: "nuc01", Flag: "noout"},
+	}, nil).NotBefore(call1).Once()
+	call3 := s.cephMock.On("SetOSDFlag", "noscrub", "").Return(nil).NotBefore(call2).Once()
+	call4 := s.cephMock.On("UnsetOSDFlag", "noout", "").Return(nil).NotBefore(call3).Once()
+	s.cephMock.On("UnsetOSDFlag", "noout", "nuc01").Return(nil).NotBefore(call4).Once()
+
+	err := s.svc.ApplyCephOSDFlags(s.ctx, desired)
+	s.Require().NoError(err)
+}
+
 func (s *serviceTestSuite) TestCheckClusterHealth() {
 	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
 		HealthStatus:    models.ClusterStatusHealthOK,
@@ -115,6 +328,10 @@ func (s *serviceTestSuite) TestCheckClusterHealth() {
 		NumOSDsByVersion: map[string]uint16{
 			"18.2.2": 15,
 		},
+		NumDaemonsByVersion: map[string]map[string]uint16{
+			"osd": {"18.2.2": 15},
+			"rgw": {"18.2.2": 5},
+		},
 		NumOSDsByDeviceType: map[string]uint16{
 			"ssd": 15,
 		},
@@ -133,6 +350,14 @@ func (s *serviceTestSuite) TestCheckClusterHealth() {
 		},
 	}, nil).Once()
 
+	s.cephMock.On("ClusterStatus").Return(models.ClusterStatus{
+		HealthStatus:   models.ClusterStatusHealthOK,
+		MonsTotal:      5,
+		QuorumAmount:   5,
+		DegradedRatio:  0.01,
+		MisplacedRatio: 0.1,
+	}, nil).Once()
+
 	s.cephMock.On("ListDevices").Return([]models.Device{
 		{
 			ID:        "testdevice",
@@ -146,8 +371,47 @@ func (s *serviceTestSuite) TestCheckClusterHealth() {
 		},
 	}, nil)
 
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("BalancerStatus").Return(models.BalancerStatus{
+		Mode:   "upmap",
+		Active: true,
+	}, nil).Once()
+	s.cephMock.On("ListOSDUsage").Return([]models.OSDUsage{
+		{
+			ID:          0,
+			DeviceClass: "ssd",
+			CapacityKB:  871899136,
+			UsedKB:      446237500,
+			AvailableKB: 425661636,
+			Utilization: 0.5117967801196255,
+			Reweight:    1,
+			NumPGs:      72,
+			Status:      "up",
+		},
+	}, nil).Once()
+	s.cephMock.On("DumpConfig").Return(models.CephConfig{
+		"global": {
+			"public_network": "192.168.1.0/24",
+		},
+	}, nil).Once()
+	s.cephMock.On("DaemonVersions").Return(map[string]map[string]uint16{
+		"mon": {"18.2.2": 5},
+		"osd": {"18.2.2": 15},
+		"rgw": {"18.2.1": 2, "18.2.2": 5},
+	}, nil).Once()
+
 	chi, err := s.svc.CheckClusterHealth(s.ctx, []clusterHeath.ClusterHealthCheck{
-		func(ctx context.Context, cr models.ClusterReport) (models.ClusterHealthIndicator, error) {
+		func(ctx context.Context, cs models.ClusterSnapshot) (models.ClusterHealthIndicator, error) {
+			s.Require().Equal(map[string]map[string]uint16{
+				"mon": {"18.2.2": 5},
+				"osd": {"18.2.2": 15},
+				"rgw": {"18.2.1": 2, "18.2.2": 5},
+			}, cs.Report.NumDaemonsByVersion)
+			s.Require().Equal(0.01, cs.Status.DegradedRatio)
+			s.Require().Len(cs.Devices, 2)
+			s.Require().Len(cs.OSDUsage, 1)
+			s.Require().Equal("192.168.1.0/24", cs.Config["global"]["public_network"])
+
 			return models.ClusterHealthIndicator{
 				Indicator:          models.ClusterHealthIndicatorTypeClusterStatus,
 				CurrentValue:       "HEALTH_OK",
@@ -165,6 +429,140 @@ func (s *serviceTestSuite) TestCheckClusterHealth() {
 	}, chi)
 }
 
+func (s *serviceTestSuite) TestCheckClusterHealthPartialData() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "nuc01", FrontIP: "192.168.1.201", BackIP: "192.168.2.231"},
+		},
+	}, nil).Once()
+	s.cephMock.On("ClusterStatus").Return(models.ClusterStatus{}, nil).Once()
+	s.cephMock.On("ListDevices").Return([]models.Device{}, nil).Once()
+	s.cephMock.On("GetMaintenanceLease").Return((*models.MaintenanceLease)(nil), nil).Once()
+	s.cephMock.On("BalancerStatus").Return(models.BalancerStatus{}, errors.New("no active manager")).Once()
+	s.cephMock.On("ListOSDUsage").Return([]models.OSDUsage{}, errors.New("no active manager")).Once()
+	s.cephMock.On("DumpConfig").Return(models.CephConfig{}, errors.New("timed out")).Once()
+	s.cephMock.On("DaemonVersions").Return(map[string]map[string]uint16{}, errors.New("timed out")).Once()
+
+	chi, err := s.svc.CheckClusterHealth(s.ctx, []clusterHeath.ClusterHealthCheck{
+		clusterHeath.Balancer,
+		clusterHeath.OSDsFullness,
+		clusterHeath.OSDsSharedNetwork,
+		clusterHeath.DaemonVersions,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]models.ClusterHealthIndicator{
+		{
+			Indicator:          models.ClusterHealthIndicatorTypeBalancer,
+			CurrentValue:       "balancer status is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		},
+		{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsFullness,
+			CurrentValue:       "OSD usage is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		},
+		{
+			Indicator:          models.ClusterHealthIndicatorTypeOSDsSharedNetwork,
+			CurrentValue:       "configuration is not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		},
+		{
+			Indicator:          models.ClusterHealthIndicatorTypeDaemonVersions,
+			CurrentValue:       "daemon versions are not available",
+			CurrentValueStatus: models.ClusterHealthIndicatorStatusUnknown,
+		},
+	}, chi)
+}
+
+func (s *serviceTestSuite) TestClusterFacts() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		NumOSDs: 3,
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "host1", MemoryTotalBytes: 64 << 30},
+			{ID: 1, Hostname: "host1", MemoryTotalBytes: 64 << 30},
+			{ID: 2, Hostname: "host2", MemoryTotalBytes: 16 << 30},
+		},
+	}, nil).Once()
+
+	facts, err := s.svc.ClusterFacts(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.ClusterFacts{
+		NumOSDs:              3,
+		NumHosts:             2,
+		MinMemoryPerOSDBytes: 16 << 30,
+		Hosts: map[string]models.HostFacts{
+			"host1": {
+				NumOSDs:           2,
+				MemoryTotalBytes:  64 << 30,
+				MemoryPerOSDBytes: 32 << 30,
+			},
+			"host2": {
+				NumOSDs:           1,
+				MemoryTotalBytes:  16 << 30,
+				MemoryPerOSDBytes: 16 << 30,
+			},
+		},
+	}, facts)
+}
+
+func (s *serviceTestSuite) TestDiffCephAuth() {
+	auth := models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+			},
+		},
+	}
+	entities := []models.AuthEntity{
+		{
+			Name: "client.rbd-prod",
+			Caps: map[string]string{},
+		},
+	}
+
+	s.cephMock.On("ListAuthEntities").Return(entities, nil).Once()
+	s.differMock.On("DiffCephAuth", entities, auth).Return([]models.CephAuthDifference{
+		{Kind: models.CephAuthDifferenceKindAdd, Entity: "client.rbd-prod", Daemon: "mon", Caps: "profile rbd"},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephAuth(s.ctx, auth)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephAuthDifference{
+		{Kind: models.CephAuthDifferenceKindAdd, Entity: "client.rbd-prod", Daemon: "mon", Caps: "profile rbd"},
+	}, diff)
+}
+
+func (s *serviceTestSuite) TestDiffCephBalancer() {
+	desired := models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 5,
+	}
+
+	s.cephMock.On("BalancerStatus").Return(models.BalancerStatus{
+		Mode:   "upmap",
+		Active: true,
+	}, nil).Once()
+	s.cephMock.On("DumpConfig").Return(models.CephConfig{
+		"mgr": {
+			"mgr/balancer/upmap_max_deviation": "1",
+		},
+	}, nil).Once()
+	s.differMock.On("DiffCephBalancer", models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 1,
+	}, desired).Return([]models.CephBalancerDifference{
+		{Key: "upmap_max_deviation", OldValue: "1", Value: "5"},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephBalancer(s.ctx, desired)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephBalancerDifference{
+		{Key: "upmap_max_deviation", OldValue: "1", Value: "5"},
+	}, diff)
+}
+
 func (s *serviceTestSuite) TestDiffCephConfig() {
 	currentConfig := models.CephConfig{
 		"osd": {
@@ -213,6 +611,112 @@ func (s *serviceTestSuite) TestDiffCephConfig() {
 	s.Require().ElementsMatch(result, diff)
 }
 
+func (s *serviceTestSuite) TestDiffCephConfigBalancerOption() {
+	currentConfig := models.CephConfig{
+		"mgr": {
+			"mgr/balancer/upmap_max_deviation": "1",
+		},
+		"osd": {
+			"test_key": "value",
+		},
+	}
+	newConfig := models.CephConfig{
+		"osd": {
+			"test_key": "value",
+		},
+	}
+
+	cephDumpConfig := s.cephMock.
+		On("DumpConfig").Return(currentConfig, nil).Once()
+	s.differMock.
+		On("DiffCephConfig", models.CephConfig{
+			"osd": {
+				"test_key": "value",
+			},
+		}, newConfig).Return([]models.CephConfigDifference{}, nil).NotBefore(cephDumpConfig).Once()
+
+	diff, err := s.svc.DiffCephConfig(s.ctx, newConfig)
+	s.Require().NoError(err)
+	s.Require().Empty(diff)
+
+	_, err = s.svc.DiffCephConfig(s.ctx, models.CephConfig{
+		"mgr": {
+			"mgr/balancer/upmap_max_deviation": "1",
+		},
+	})
+	s.Require().Error(err)
+	s.Require().ErrorIs(err, ErrBalancerOption)
+}
+
+func (s *serviceTestSuite) TestDiffCephFS() {
+	fs := models.CephFS{
+		"shared-data": {
+			SessionTimeout: ptr.Uint32(120),
+		},
+	}
+	filesystems := []models.FileSystem{
+		{
+			Name:               "shared-data",
+			MaxMDS:             1,
+			AllowStandbyReplay: false,
+			SessionTimeout:     60,
+			MaxFileSize:        1099511627776,
+			Joinable:           true,
+		},
+	}
+
+	s.cephMock.On("ListFileSystems").Return(filesystems, nil).Once()
+	s.differMock.On("DiffCephFS", filesystems, fs).Return([]models.CephFSDifference{
+		{FileSystem: "shared-data", Key: "session_timeout", OldValue: "60", Value: "120"},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephFS(s.ctx, fs)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephFSDifference{
+		{FileSystem: "shared-data", Key: "session_timeout", OldValue: "60", Value: "120"},
+	}, diff)
+}
+
+func (s *serviceTestSuite) TestDiffCephHealthMutes() {
+	mutes := models.CephHealthMutes{
+		"OSDMAP_FLAGS": {TTL: time.Hour},
+	}
+
+	s.cephMock.On("ClusterStatus").Return(models.ClusterStatus{
+		MutedChecks: []models.ClusterStatusMutedCheck{},
+	}, nil).Once()
+	s.differMock.On("DiffCephHealthMutes", []models.ClusterStatusMutedCheck{}, mutes).Return([]models.CephHealthMutesDifference{
+		{Kind: models.CephHealthMutesDifferenceKindMute, Code: "OSDMAP_FLAGS", Mute: mutes["OSDMAP_FLAGS"]},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephHealthMutes(s.ctx, mutes)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephHealthMutesDifference{
+		{Kind: models.CephHealthMutesDifferenceKindMute, Code: "OSDMAP_FLAGS", Mute: mutes["OSDMAP_FLAGS"]},
+	}, diff)
+}
+
+func (s *serviceTestSuite) TestDiffCephMgrModules() {
+	current := models.MgrModules{
+		Enabled:  []string{"restful"},
+		Disabled: []string{"prometheus"},
+	}
+	desired := models.CephMgrModules{
+		Enabled: []string{"prometheus"},
+	}
+
+	s.cephMock.On("ListMgrModules").Return(current, nil).Once()
+	s.differMock.On("DiffCephMgrModules", current, desired).Return([]models.CephMgrModulesDifference{
+		{Kind: models.CephMgrModulesDifferenceKindEnable, Module: "prometheus"},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephMgrModules(s.ctx, desired)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephMgrModulesDifference{
+		{Kind: models.CephMgrModulesDifferenceKindEnable, Module: "prometheus"},
+	}, diff)
+}
+
 func (s *serviceTestSuite) TestDiffCephOSDConfig() {
 	src := models.CephOSDConfig{
 		AllowCrimson:           false,
@@ -297,8 +801,87 @@ func (s *serviceTestSuite) TestDiffCephOSDConfig() {
 	}, diff)
 }
 
+func (s *serviceTestSuite) TestDiffCephOSDFlags() {
+	current := models.CephOSDFlags{
+		Cluster: []string{"noout"},
+	}
+	desired := models.CephOSDFlags{}
+
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDFlags: current,
+	}, nil).Once()
+	s.differMock.On("DiffCephOSDFlags", current, desired).Return([]models.CephOSDFlagsDifference{
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "noout"},
+	}, nil).Once()
+
+	diff, err := s.svc.DiffCephOSDFlags(s.ctx, desired)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.CephOSDFlagsDifference{
+		{Kind: models.CephOSDFlagsDifferenceKindUnset, Flag: "noout"},
+	}, diff)
+}
+
+func (s *serviceTestSuite) TestDumpAuth() {
+	entities := []models.AuthEntity{
+		{
+			Name: "client.rbd-prod",
+			Key:  "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+			Caps: map[string]string{
+				"mon": "profile rbd",
+			},
+		},
+		{
+			Name: "client.bootstrap-unused",
+			Key:  "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+			Caps: map[string]string{},
+		},
+	}
+
+	s.cephMock.On("ListAuthEntities").Return(entities, nil).Twice()
+
+	auth, err := s.svc.DumpAuth(s.ctx, false)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+			},
+		},
+	}, auth)
+
+	auth, err = s.svc.DumpAuth(s.ctx, true)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephAuth{
+		"client.rbd-prod": {
+			Caps: map[string]string{
+				"mon": "profile rbd",
+			},
+			Key: "AQCu2UJmAAAAABAAqvDyTcI4lzVbKLoFxs9TKQ==",
+		},
+	}, auth)
+}
+
+func (s *serviceTestSuite) TestDumpBalancer() {
+	s.cephMock.On("BalancerStatus").Return(models.BalancerStatus{
+		Mode:   "upmap",
+		Active: true,
+	}, nil).Once()
+	s.cephMock.On("DumpConfig").Return(models.CephConfig{}, nil).Once()
+
+	cfg, err := s.svc.DumpBalancer(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephBalancer{
+		Mode:              "upmap",
+		Active:            true,
+		UpmapMaxDeviation: 5,
+	}, cfg)
+}
+
 func (s *serviceTestSuite) TestDumpConfig() {
 	s.cephMock.On("DumpConfig").Return(models.CephConfig{
+		"mgr": {
+			"mgr/balancer/upmap_max_deviation": "1",
+		},
 		"osd": {
 			"test_key": "value",
 		},
@@ -313,6 +896,65 @@ func (s *serviceTestSuite) TestDumpConfig() {
 	}, cfg)
 }
 
+func (s *serviceTestSuite) TestDumpFS() {
+	filesystems := []models.FileSystem{
+		{
+			Name:               "shared-data",
+			MaxMDS:             1,
+			AllowStandbyReplay: false,
+			SessionTimeout:     60,
+			MaxFileSize:        1099511627776,
+			Joinable:           true,
+		},
+	}
+
+	s.cephMock.On("ListFileSystems").Return(filesystems, nil).Once()
+
+	fs, err := s.svc.DumpFS(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephFS{
+		"shared-data": {
+			MaxMDS:             ptr.Uint16(1),
+			AllowStandbyReplay: ptr.Bool(false),
+			SessionTimeout:     ptr.Uint32(60),
+			MaxFileSize:        ptr.Uint64(1099511627776),
+			Joinable:           ptr.Bool(true),
+		},
+	}, fs)
+}
+
+func (s *serviceTestSuite) TestDumpHealthMutes() {
+	expiredAt := time.Now().Add(-time.Minute)
+
+	s.cephMock.On("ClusterStatus").Return(models.ClusterStatus{
+		MutedChecks: []models.ClusterStatusMutedCheck{
+			{Code: "OSD_NEARFULL", Sticky: true},
+			{Code: "OSDMAP_FLAGS", ExpiresAt: &expiredAt},
+		},
+	}, nil).Once()
+
+	mutes, err := s.svc.DumpHealthMutes(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephHealthMutes{
+		"OSD_NEARFULL": {Sticky: true},
+	}, mutes)
+}
+
+func (s *serviceTestSuite) TestDumpMgrModules() {
+	s.cephMock.On("ListMgrModules").Return(models.MgrModules{
+		AlwaysOn: []string{"balancer"},
+		Enabled:  []string{"restful"},
+		Disabled: []string{"prometheus"},
+	}, nil).Once()
+
+	modules, err := s.svc.DumpMgrModules(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephMgrModules{
+		Enabled:  []string{"restful"},
+		Disabled: []string{"prometheus"},
+	}, modules)
+}
+
 func (s *serviceTestSuite) TestDumpOSDConfig() {
 	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
 		AllowCrimson:           true,
@@ -333,6 +975,26 @@ func (s *serviceTestSuite) TestDumpOSDConfig() {
 	}, cfg)
 }
 
+func (s *serviceTestSuite) TestDumpOSDFlags() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDFlags: models.CephOSDFlags{
+			Cluster: []string{"noout", "noscrub"},
+			OSDs: map[string][]string{
+				"osd.3": {"noin"},
+			},
+		},
+	}, nil).Once()
+
+	flags, err := s.svc.DumpOSDFlags(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(models.CephOSDFlags{
+		Cluster: []string{"noout", "noscrub"},
+		OSDs: map[string][]string{
+			"osd.3": {"noin"},
+		},
+	}, flags)
+}
+
 // Definitions ...
 
 type serviceTestSuite struct {
@@ -345,6 +1007,47 @@ type serviceTestSuite struct {
 	svc        Service
 }
 
+func (s *serviceTestSuite) TestRecommendOSDMemoryTarget() {
+	s.cephMock.On("ClusterReport").Return(models.ClusterReport{
+		OSDDaemons: []models.OSDDaemon{
+			{ID: 0, Hostname: "host2", MemoryTotalBytes: 64 << 30, IsRotational: true},
+			{ID: 1, Hostname: "host2", MemoryTotalBytes: 64 << 30, IsRotational: true},
+			{ID: 2, Hostname: "host2", MemoryTotalBytes: 64 << 30, IsRotational: false},
+			{ID: 3, Hostname: "host2", MemoryTotalBytes: 64 << 30, IsRotational: false},
+			{ID: 4, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+			{ID: 5, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+			{ID: 6, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+			{ID: 7, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+			{ID: 8, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+			{ID: 9, Hostname: "host1", MemoryTotalBytes: 8 << 30},
+		},
+	}, nil).Once()
+
+	recs, err := s.svc.RecommendOSDMemoryTarget(s.ctx, 4<<30, 0.2)
+	s.Require().NoError(err)
+	s.Require().Equal([]models.OSDMemoryRecommendation{
+		{
+			Hostname:             "host1",
+			NumOSDs:              6,
+			MemoryTotalBytes:     8 << 30,
+			OSDMemoryTargetBytes: 896 << 20,
+			Insufficient:         true,
+		},
+		{
+			Hostname:             "host2",
+			NumOSDs:              4,
+			NumRotationalOSDs:    2,
+			MemoryTotalBytes:     64 << 30,
+			OSDMemoryTargetBytes: 12288 << 20,
+		},
+	}, recs)
+}
+
+func (s *serviceTestSuite) TestRecommendOSDMemoryTargetInvalidHeadroom() {
+	_, err := s.svc.RecommendOSDMemoryTarget(s.ctx, 4<<30, 1)
+	s.Require().Error(err)
+}
+
 func (s *serviceTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithTimeout(context.Background(), 3*time.Second)
 